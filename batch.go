@@ -0,0 +1,173 @@
+package revealer
+
+import (
+	"context"
+	"runtime"
+)
+
+// BatchResult is the outcome for one input of a batch.
+type BatchResult struct {
+	// Index is the position of Input in the batch, from 0.
+	Index int
+	Input string
+
+	// Result is the revealed address, or nil when Err is set.
+	Result *Result
+	Err    error
+}
+
+// BatchOption configures FixAll and FixSlice.
+type BatchOption func(*batch)
+
+// batch is the configuration of one batch.
+type batch struct {
+	workers  int
+	progress func(done, failed int)
+}
+
+// WithWorkers sets how many addresses are revealed at once. It
+// defaults to runtime.GOMAXPROCS(0).
+func WithWorkers(n int) BatchOption {
+	return func(b *batch) {
+		if n > 0 {
+			b.workers = n
+		}
+	}
+}
+
+// WithProgress calls progress after each result is delivered, with
+// the counts so far. It is called from one goroutine at a time.
+func WithProgress(progress func(done, failed int)) BatchOption {
+	return func(b *batch) {
+		b.progress = progress
+	}
+}
+
+// FixAll reveals the addresses from inputs using the default Revealer.
+// See Revealer.FixAll.
+func FixAll(ctx context.Context, inputs <-chan string, opts ...BatchOption) <-chan BatchResult {
+	return defaultRevealer.FixAll(ctx, inputs, opts...)
+}
+
+// FixSlice reveals inputs using the default Revealer. See
+// Revealer.FixSlice.
+func FixSlice(inputs []string, opts ...BatchOption) []BatchResult {
+	return defaultRevealer.FixSlice(inputs, opts...)
+}
+
+// FixAll reveals the addresses from inputs across a pool of workers.
+// The results come out in the order the inputs went in, and the
+// channel is closed once inputs is closed and drained, or ctx is done.
+// Only a few results per worker are held while waiting for an earlier
+// one, so inputs may be endless.
+func (r *Revealer) FixAll(ctx context.Context, inputs <-chan string, opts ...BatchOption) <-chan BatchResult {
+	b := batch{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&b)
+	}
+
+	var (
+		jobs   = make(chan BatchResult)
+		done   = make(chan BatchResult)
+		out    = make(chan BatchResult)
+		window = make(chan struct{}, 4*b.workers) // results in flight
+		exited = make(chan struct{})
+	)
+
+	// hand out the inputs in order
+	go func() {
+		defer close(jobs)
+		for i := 0; ; i++ {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case input, ok := <-inputs:
+				if !ok {
+					return
+				}
+				select {
+				case jobs <- BatchResult{Index: i, Input: input}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for w := 0; w < b.workers; w++ {
+		go func() {
+			defer func() { exited <- struct{}{} }()
+			for job := range jobs {
+				job.Result, job.Err = r.Reveal(job.Input)
+				select {
+				case done <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		for w := 0; w < b.workers; w++ {
+			<-exited
+		}
+		close(done)
+	}()
+
+	// put the results back in order
+	go func() {
+		defer close(out)
+		var (
+			pending = make(map[int]BatchResult)
+			next    int
+			failed  int
+		)
+		for result := range done {
+			pending[result.Index] = result
+			for {
+				result, ok := pending[next]
+				if !ok {
+					break
+				}
+				select {
+				case out <- result:
+				case <-ctx.Done():
+					return
+				}
+				delete(pending, next)
+				<-window
+				next++
+				if result.Err != nil {
+					failed++
+				}
+				if b.progress != nil {
+					b.progress(next, failed)
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// FixSlice reveals inputs like FixAll, returning a result for each.
+func (r *Revealer) FixSlice(inputs []string, opts ...BatchOption) []BatchResult {
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, input := range inputs {
+			in <- input
+		}
+	}()
+
+	results := make([]BatchResult, 0, len(inputs))
+	for result := range r.FixAll(context.Background(), in, opts...) {
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,74 @@
+package revealer
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFixSlice(t *testing.T) {
+
+	inputs := make([]string, len(fixerTests))
+	for i, test := range fixerTests {
+		inputs[i] = test.email
+	}
+	inputs = append(inputs, "broken")
+
+	var calls, lastDone, lastFailed int
+	results := FixSlice(inputs, WithWorkers(4), WithProgress(func(done, failed int) {
+		calls++
+		lastDone, lastFailed = done, failed
+	}))
+
+	if len(results) != len(inputs) {
+		t.Fatalf("Expected %d results, Actual: %d", len(inputs), len(results))
+	}
+	for i, test := range fixerTests {
+		result := results[i]
+		if result.Index != i || result.Input != test.email {
+			t.Errorf("Expected result %d for %s, Actual: %d for %s", i, test.email, result.Index, result.Input)
+		}
+		if result.Err != nil {
+			t.Errorf("Error: %s", result.Err)
+			continue
+		}
+		if result.Result.Address != test.expectedResult {
+			t.Errorf("Expected: %s, Actual: %s", test.expectedResult, result.Result.Address)
+		}
+	}
+	if last := results[len(results)-1]; last.Err == nil || last.Result != nil {
+		t.Errorf("Should have errored!")
+	}
+	if calls != len(inputs) || lastDone != len(inputs) || lastFailed != 1 {
+		t.Errorf("Expected progress %d/1 over %d calls, Actual: %d/%d over %d", len(inputs), len(inputs), lastDone, lastFailed, calls)
+	}
+}
+
+func TestFixAllCancel(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// an endless supply of addresses
+	inputs := make(chan string)
+	go func() {
+		for {
+			select {
+			case inputs <- "y.imai at ocaml.jp":
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := FixAll(ctx, inputs, WithWorkers(3))
+	for i := 0; i < 100; i++ {
+		result := <-results
+		if result.Index != i {
+			t.Fatalf("Expected result %d, Actual: %d", i, result.Index)
+		}
+	}
+	cancel()
+
+	// the results channel is closed soon after
+	for range results {
+	}
+}
@@ -0,0 +1,122 @@
+package revealer
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxAtSpaces limits how many spaces Candidates tries as the "@".
+const maxAtSpaces = 8
+
+// variant holds the choices that Fix always makes one way but
+// Candidates tries every way.
+type variant struct {
+	spaces  spaceMode
+	keepTag bool
+
+	// atSpace, when there is no "@", is the space (counting from 1)
+	// to use as one.
+	atSpace int
+
+	// decoder names the decoder email was decoded with, if any.
+	decoder string
+}
+
+// spaceMode is how addDots treats the spaces in a name.
+type spaceMode int
+
+const (
+	spacesDefault spaceMode = iota // dots, unless there are more than three parts
+	spacesDotted                   // always dots
+	spacesDropped                  // always dropped
+)
+
+// guesses are the guesses v makes beyond Fix.
+func (v variant) guesses() []string {
+	var names []string
+	switch v.spaces {
+	case spacesDotted:
+		names = append(names, GuessSpacesDotted)
+	case spacesDropped:
+		names = append(names, GuessSpacesDropped)
+	}
+	if v.keepTag {
+		names = append(names, GuessTagKept)
+	}
+	if v.decoder != "" {
+		names = append(names, GuessDecoded)
+	}
+	return names
+}
+
+// variants lists the variants worth trying for email, Fix's own first.
+func variants(email string) []variant {
+	atSpaces := strings.Count(strings.TrimSpace(email), " ")
+	if atSpaces > maxAtSpaces {
+		atSpaces = maxAtSpaces
+	}
+
+	var vs []variant
+	for _, spaces := range []spaceMode{spacesDefault, spacesDotted, spacesDropped} {
+		for _, keepTag := range []bool{false, true} {
+			for at := 0; at <= atSpaces; at++ {
+				vs = append(vs, variant{spaces: spaces, keepTag: keepTag, atSpace: at})
+			}
+		}
+	}
+	return vs
+}
+
+// Candidates returns up to n distinct valid readings of an ambiguous
+// address, using the default Revealer. See Revealer.Candidates.
+func Candidates(email string, n int) ([]*Result, error) {
+	return defaultRevealer.Candidates(email, n)
+}
+
+// Candidates returns up to n distinct valid readings of an ambiguous
+// address, best first by Confidence. Alongside what Fix does it tries
+// each space as the "@", dotting or dropping the spaces in the name,
+// and keeping a "+tag". If n <= 0 every reading is returned. The error
+// is the one Reveal gives when nothing valid is found.
+func (r *Revealer) Candidates(email string, n int) ([]*Result, error) {
+	var (
+		results []*Result
+		seen    = make(map[string]*Result)
+		first   error
+	)
+	t := r.tracerFor(email)
+	for i, v := range variants(email) {
+		result, err := r.reveal(email, v, t)
+		if err != nil {
+			if i == 0 {
+				first = err
+			}
+			continue
+		}
+		if best, ok := seen[result.Address]; ok {
+			if result.Confidence > best.Confidence {
+				*best = *result
+			}
+			continue
+		}
+		seen[result.Address] = result
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return nil, first
+	}
+
+	// on a tie, the shorter domain is the likelier one, e.g.
+	// "john.smith@example.org" before "john@smith.example.org"
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Confidence != results[j].Confidence {
+			return results[i].Confidence > results[j].Confidence
+		}
+		return strings.Count(results[i].Domain, ".") < strings.Count(results[j].Domain, ".")
+	})
+	if n > 0 && len(results) > n {
+		results = results[:n]
+	}
+	return results, nil
+}
@@ -0,0 +1,45 @@
+package revealer
+
+import "testing"
+
+func TestCandidates(t *testing.T) {
+
+	var tests = []struct {
+		email    string
+		n        int
+		expected []string
+	}{
+		{"frode andre petterson (at) gmail com", 0, []string{"frode.andre.petterson@gmail.com", "frodeandrepetterson@gmail.com"}},
+		{"ev45ive + github @gmail.com", 0, []string{"ev45ive@gmail.com", "ev45ive+github@gmail.com"}},
+		{"t e s t 7 @ f o o . c o m", 0, []string{"test7@foo.com", "t.e.s.t.7@foo.com"}},
+		{"john smith example.org", 0, []string{"john.smith@example.org", "john@smith.example.org", "johnsmith@example.org"}},
+		{"john smith example.org", 1, []string{"john.smith@example.org"}},
+		{"debackerl gmail com", 3, []string{"debackerl@gmail.com"}},
+	}
+
+	for _, test := range tests {
+		results, err := Candidates(test.email, test.n)
+		if err != nil {
+			t.Errorf("Error: %s", err)
+			continue
+		}
+		if len(results) != len(test.expected) {
+			t.Errorf("%s: expected %d candidates, Actual: %d", test.email, len(test.expected), len(results))
+			continue
+		}
+		for i, result := range results {
+			if result.Address != test.expected[i] {
+				t.Errorf("Expected: %s, Actual: %s", test.expected[i], result.Address)
+			}
+			if i > 0 && result.Confidence > results[i-1].Confidence {
+				t.Errorf("%s: candidates out of order", test.email)
+			}
+		}
+	}
+
+	// nothing valid gives Fix's error
+	_, err := Candidates("broken", 5)
+	if err == nil || err.Error() != "unable to fix email address: broken -> broken" {
+		t.Errorf("Expected Err: unable to fix email address: broken -> broken, Actual Err: %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dstroot/revealer"
+)
+
+// csvColumns are added to every record in CSV mode.
+var csvColumns = []string{"email_fixed", "fix_status", "fix_error"}
+
+// errMissingColumn is the fix_error for a record too short to have
+// the address column.
+var errMissingColumn = errors.New("missing column")
+
+// csvReader reads CSV records one at a time, keeping the raw text of
+// each so it can be written back exactly as it was, quoting and all.
+type csvReader struct {
+	r     *bufio.Reader
+	comma rune
+}
+
+// record is one CSV record.
+type record struct {
+	raw    string // the record as read, without its line ending
+	eol    string // "\n", "\r\n", or "" at the end of the input
+	fields []string
+}
+
+// Read returns the next record, or io.EOF when there are no more.
+// Quoted fields may hold commas, newlines and doubled quotes; a quote
+// anywhere else is taken literally.
+func (c *csvReader) Read() (*record, error) {
+	var (
+		rec    record
+		raw    strings.Builder
+		field  strings.Builder
+		quoted bool // inside a quoted field
+		start  = true
+	)
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line == "" && err == io.EOF {
+			if raw.Len() == 0 {
+				return nil, io.EOF
+			}
+			// a quoted field left open at the end of the input
+			rec.raw = raw.String()
+			rec.fields = append(rec.fields, field.String())
+			return &rec, nil
+		}
+
+		body, eol := splitEOL(line)
+		raw.WriteString(body)
+		for i := 0; i < len(body); {
+			ch, size := utf8.DecodeRuneInString(body[i:])
+			switch {
+			case quoted && ch == '"' && strings.HasPrefix(body[i+size:], `"`):
+				field.WriteRune('"')
+				size++
+			case quoted && ch == '"':
+				quoted = false
+			case quoted:
+				field.WriteRune(ch)
+			case start && ch == '"':
+				quoted = true
+				start = false
+			case ch == c.comma:
+				rec.fields = append(rec.fields, field.String())
+				field.Reset()
+				start = true
+			default:
+				field.WriteRune(ch)
+				start = false
+			}
+			i += size
+		}
+
+		if quoted && err == nil {
+			// the newline belongs to the quoted field
+			raw.WriteString(eol)
+			field.WriteString(eol)
+			continue
+		}
+		rec.raw, rec.eol = raw.String(), eol
+		rec.fields = append(rec.fields, field.String())
+		return &rec, nil
+	}
+}
+
+// splitEOL splits the line ending off line.
+func splitEOL(line string) (string, string) {
+	switch {
+	case strings.HasSuffix(line, "\r\n"):
+		return line[:len(line)-2], "\r\n"
+	case strings.HasSuffix(line, "\n"):
+		return line[:len(line)-1], "\n"
+	}
+	return line, ""
+}
+
+// csvField quotes s for a CSV field when it needs it.
+func csvField(s string, comma rune) string {
+	if s == "" || !strings.ContainsAny(s, "\"\r\n"+string(comma)) && s[0] != ' ' {
+		return s
+	}
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
+
+// fixCSV copies in to out, adding csvColumns to each record with the
+// address in column revealed. column is a header name, or a 1-based
+// index. Only one record is held in memory at a time.
+func fixCSV(r *revealer.Revealer, in io.Reader, out io.Writer, comma rune, column string, header bool) (total, failed int, err error) {
+	var (
+		cr    = &csvReader{r: bufio.NewReaderSize(in, 64*1024), comma: comma}
+		w     = bufio.NewWriter(out)
+		index = -1
+		sep   = string(comma)
+	)
+	defer w.Flush()
+
+	if n, err := strconv.Atoi(column); err == nil {
+		if n < 1 {
+			return 0, 0, fmt.Errorf("column %d: columns are numbered from 1", n)
+		}
+		index = n - 1
+	}
+
+	if header {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return 0, 0, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		if index < 0 {
+			for i, name := range rec.fields {
+				if name == column {
+					index = i
+					break
+				}
+			}
+		}
+		if index < 0 {
+			return 0, 0, fmt.Errorf("column %q not found in header", column)
+		}
+		w.WriteString(rec.raw + sep + strings.Join(csvColumns, sep) + rec.eol)
+	} else if index < 0 {
+		return 0, 0, fmt.Errorf("column %q: a name needs a header, use an index", column)
+	}
+
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return total, failed, nil
+		}
+		if err != nil {
+			return total, failed, err
+		}
+		if rec.raw == "" {
+			// keep blank lines as they were
+			w.WriteString(rec.eol)
+			continue
+		}
+
+		var (
+			result *revealer.Result
+			rerr   = errMissingColumn
+		)
+		if index < len(rec.fields) {
+			result, rerr = r.Reveal(rec.fields[index])
+		}
+		total++
+
+		fixed, status, msg := "", "ok", ""
+		if rerr != nil {
+			failed++
+			status, msg = "error", rerr.Error()
+		} else {
+			fixed = result.Address
+		}
+		w.WriteString(rec.raw + sep + csvField(fixed, comma) + sep + status + sep + csvField(msg, comma) + rec.eol)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dstroot/revealer"
+)
+
+func TestCSVReader(t *testing.T) {
+
+	in := "a,\"b,c\",\"d \"\"e\"\"\"\r\n\"multi\nline\",x\n\nlast"
+	cr := &csvReader{r: bufio.NewReader(strings.NewReader(in)), comma: ','}
+
+	var tests = []struct {
+		raw    string
+		eol    string
+		fields []string
+	}{
+		{"a,\"b,c\",\"d \"\"e\"\"\"", "\r\n", []string{"a", "b,c", "d \"e\""}},
+		{"\"multi\nline\",x", "\n", []string{"multi\nline", "x"}},
+		{"", "\n", []string{""}},
+		{"last", "", []string{"last"}},
+	}
+
+	for _, test := range tests {
+		rec, err := cr.Read()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if rec.raw != test.raw || rec.eol != test.eol || strings.Join(rec.fields, "|") != strings.Join(test.fields, "|") {
+			t.Errorf("Expected: %q %q %q, Actual: %q %q %q", test.raw, test.eol, test.fields, rec.raw, rec.eol, rec.fields)
+		}
+	}
+	if _, err := cr.Read(); err != io.EOF {
+		t.Errorf("Expected: EOF, Actual: %v", err)
+	}
+}
+
+func TestFixCSV(t *testing.T) {
+
+	var tests = []struct {
+		in       string
+		comma    rune
+		column   string
+		header   bool
+		expected string
+		total    int
+		failed   int
+	}{
+		{"id,\"e,mail\"\r\n1,\"y.imai at ocaml.jp\"\r\n\r\n2,broken\r\n3\r\n", ',', "e,mail", true,
+			"id,\"e,mail\",email_fixed,fix_status,fix_error\r\n" +
+				"1,\"y.imai at ocaml.jp\",y.imai@ocaml.jp,ok,\r\n" +
+				"\r\n" +
+				"2,broken,,error,unable to fix email address: broken -> broken\r\n" +
+				"3,,error,missing column\r\n", 3, 2},
+		{"x\ty.imai at ocaml.jp\n", '\t', "2", false,
+			"x\ty.imai at ocaml.jp\ty.imai@ocaml.jp\tok\t\n", 1, 0},
+		{"name,email\n\"Doe, J\",\"a \"\"b\"\"\"", ',', "email", true,
+			"name,email,email_fixed,fix_status,fix_error\n" +
+				"\"Doe, J\",\"a \"\"b\"\"\",,error,\"unable to fix email address: a \"\"b\"\" -> a@\"\"b\"\"\"", 1, 1},
+	}
+
+	for _, test := range tests {
+		var out bytes.Buffer
+		total, failed, err := fixCSV(revealer.New(), strings.NewReader(test.in), &out, test.comma, test.column, test.header)
+		if err != nil {
+			t.Errorf("Error: %s", err)
+		}
+		if out.String() != test.expected {
+			t.Errorf("Expected: %q, Actual: %q", test.expected, out.String())
+		}
+		if total != test.total || failed != test.failed {
+			t.Errorf("Expected: %d/%d failed, Actual: %d/%d", test.failed, test.total, failed, total)
+		}
+	}
+
+	// bad columns
+	for _, column := range []string{"nope", "0"} {
+		_, _, err := fixCSV(revealer.New(), strings.NewReader("a,b\n"), &bytes.Buffer{}, ',', column, true)
+		if err == nil {
+			t.Errorf("%s: should have errored!", column)
+		}
+	}
+}
+
+func TestRunCSV(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-csv", "-", "-column", "email"}, strings.NewReader("email\ny.imai at ocaml.jp\n"), &stdout, &stderr)
+	if code != exitOK {
+		t.Errorf("Expected exit code %d, Actual: %d (%s)", exitOK, code, stderr.String())
+	}
+	expected := "email,email_fixed,fix_status,fix_error\ny.imai at ocaml.jp,y.imai@ocaml.jp,ok,\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected: %q, Actual: %q", expected, stdout.String())
+	}
+
+	code = run([]string{"-csv", "-"}, strings.NewReader(""), &stdout, &stderr)
+	if code != exitUsage {
+		t.Errorf("Expected exit code %d, Actual: %d", exitUsage, code)
+	}
+}
@@ -0,0 +1,256 @@
+// Command revealer "de-obfuscates" email addresses in bulk.
+//
+// Usage:
+//
+//	revealer [flags] [address ...]
+//
+// Addresses are read from the arguments, or else one per line from the
+// files given with -f, or else from standard input. Blank lines are
+// skipped. Each address is printed revealed, in the order read, in one
+// of these formats:
+//
+//	plain   the address, or the -error marker when it cannot be fixed
+//	tsv     input, address, "ok" or "error", and the error
+//	json    one JSON object per line
+//
+// With -csv the input is instead a CSV or TSV file (- for standard
+// input) with the addresses in the -column named, or numbered from 1.
+// It is written to standard output as it was, quoting included, with
+// email_fixed, fix_status and fix_error columns added to each record.
+// The file is streamed, so it can be of any size.
+//
+// The exit code is 0 when every address was fixed, 1 when some were
+// not, 2 when none were, and 3 for bad flags or unreadable input.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dstroot/revealer"
+)
+
+// Exit codes.
+const (
+	exitOK = iota
+	exitSomeFailed
+	exitAllFailed
+	exitUsage
+)
+
+// files collects the repeatable -f flag.
+type files []string
+
+func (f *files) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *files) Set(name string) error {
+	*f = append(*f, name)
+	return nil
+}
+
+// line is one address in the json format.
+type line struct {
+	Input      string   `json:"input"`
+	Address    string   `json:"address,omitempty"`
+	Confidence float64  `json:"confidence,omitempty"`
+	Steps      []string `json:"steps,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run is main, apart from the process, so it can be tested.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var (
+		inputs files
+		fs     = flag.NewFlagSet("revealer", flag.ContinueOnError)
+		format = fs.String("format", "plain", "output `format`: plain, tsv or json")
+		marker = fs.String("error", "!", "`marker` printed in plain format for an address that cannot be fixed")
+		trace  = fs.Bool("trace", false, "print each pipeline step to standard error")
+		csv    = fs.String("csv", "", "fix a column of this CSV or TSV `file` (- for standard input)")
+		column = fs.String("column", "", "in -csv mode, the `name` or number (from 1) of the address column")
+		comma  = fs.String("comma", "", "in -csv mode, the field `separator`; defaults to tab for .tsv files, else comma")
+		header = fs.Bool("header", true, "in -csv mode, the first record is a header")
+	)
+	fs.Var(&inputs, "f", "read addresses from `file`, one per line (repeatable, - for standard input)")
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	var write func(w *bufio.Writer, input string, result *revealer.Result, err error)
+	switch *format {
+	case "plain":
+		write = func(w *bufio.Writer, input string, result *revealer.Result, err error) {
+			if err != nil {
+				fmt.Fprintln(w, *marker)
+				return
+			}
+			fmt.Fprintln(w, result.Address)
+		}
+	case "tsv":
+		write = func(w *bufio.Writer, input string, result *revealer.Result, err error) {
+			if err != nil {
+				fmt.Fprintf(w, "%s\t\terror\t%s\n", tsvField(input), tsvField(err.Error()))
+				return
+			}
+			fmt.Fprintf(w, "%s\t%s\tok\t\n", tsvField(input), result.Address)
+		}
+	case "json":
+		write = func(w *bufio.Writer, input string, result *revealer.Result, err error) {
+			l := line{Input: input}
+			if err != nil {
+				l.Error = err.Error()
+			} else {
+				l.Address, l.Confidence, l.Steps = result.Address, result.Confidence, result.Steps
+			}
+			enc := json.NewEncoder(w)
+			enc.SetEscapeHTML(false)
+			enc.Encode(l)
+		}
+	default:
+		fmt.Fprintf(stderr, "revealer: unknown format %q\n", *format)
+		fs.Usage()
+		return exitUsage
+	}
+
+	var r *revealer.Revealer
+	if *trace {
+		r = revealer.New(revealer.WithTracer(revealer.LogTracer(log.New(stderr, "", 0))))
+	} else {
+		r = revealer.New()
+	}
+
+	if *csv != "" {
+		total, failed, err := runCSV(r, *csv, *column, *comma, *header, stdin, stdout)
+		if err != nil {
+			fmt.Fprintf(stderr, "revealer: %s\n", err)
+			return exitUsage
+		}
+		return exitCode(total, failed)
+	}
+
+	w := bufio.NewWriter(stdout)
+	defer w.Flush()
+
+	var total, failed int
+	fix := func(input string) {
+		if strings.TrimSpace(input) == "" {
+			return
+		}
+		if *trace {
+			// keep the output next to its trace
+			w.Flush()
+		}
+		result, err := r.Reveal(input)
+		total++
+		if err != nil {
+			failed++
+		}
+		write(w, input, result, err)
+	}
+
+	switch {
+	case fs.NArg() > 0:
+		for _, input := range fs.Args() {
+			fix(input)
+		}
+	case len(inputs) > 0:
+		for _, name := range inputs {
+			if err := readLines(name, stdin, fix); err != nil {
+				w.Flush()
+				fmt.Fprintf(stderr, "revealer: %s\n", err)
+				return exitUsage
+			}
+		}
+	default:
+		if err := readLines("-", stdin, fix); err != nil {
+			w.Flush()
+			fmt.Fprintf(stderr, "revealer: %s\n", err)
+			return exitUsage
+		}
+	}
+
+	return exitCode(total, failed)
+}
+
+// exitCode is the exit code when failed of total addresses failed.
+func exitCode(total, failed int) int {
+	switch {
+	case failed == 0:
+		return exitOK
+	case failed < total:
+		return exitSomeFailed
+	}
+	return exitAllFailed
+}
+
+// runCSV fixes the column of the named CSV file, writing it to stdout.
+func runCSV(r *revealer.Revealer, name, column, comma string, header bool, stdin io.Reader, stdout io.Writer) (total, failed int, err error) {
+	if column == "" {
+		return 0, 0, errors.New("-csv needs a -column")
+	}
+
+	sep := ','
+	switch {
+	case comma == `\t` || comma == "tab":
+		sep = '\t'
+	case comma != "":
+		c, size := utf8.DecodeRuneInString(comma)
+		if size != len(comma) {
+			return 0, 0, fmt.Errorf("-comma %q is not a single character", comma)
+		}
+		sep = c
+	case strings.HasSuffix(strings.ToLower(name), ".tsv"):
+		sep = '\t'
+	}
+
+	in := stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return 0, 0, err
+		}
+		defer f.Close()
+		in = f
+	}
+	return fixCSV(r, in, stdout, sep, column, header)
+}
+
+// readLines calls fn with each line of the named file, or of stdin
+// when name is "-".
+func readLines(name string, stdin io.Reader, fn func(string)) error {
+	r := stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+	for s.Scan() {
+		fn(strings.TrimRight(s.Text(), "\r"))
+	}
+	return s.Err()
+}
+
+// tsvField keeps tabs and newlines in s from breaking a TSV row.
+func tsvField(s string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(s)
+}
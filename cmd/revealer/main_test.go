@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "revealer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "emails.txt")
+	if err := ioutil.WriteFile(file, []byte("y.imai at ocaml.jp\r\n\r\nbroken\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		args     []string
+		stdin    string
+		expected string
+		code     int
+	}{
+		{[]string{"y.imai at ocaml.jp", "zxytim[at]gmail[dot]com"}, "",
+			"y.imai@ocaml.jp\nzxytim@gmail.com\n", exitOK},
+		{nil, "y.imai at ocaml.jp\n\nbroken\n",
+			"y.imai@ocaml.jp\n!\n", exitSomeFailed},
+		{[]string{"-f", file, "-error", "ERROR"}, "",
+			"y.imai@ocaml.jp\nERROR\n", exitSomeFailed},
+		{[]string{"-format", "tsv", "broken"}, "",
+			"broken\t\terror\tunable to fix email address: broken -> broken\n", exitAllFailed},
+		{[]string{"-format", "tsv", "y.imai at ocaml.jp"}, "",
+			"y.imai at ocaml.jp\ty.imai@ocaml.jp\tok\t\n", exitOK},
+		{[]string{"-format", "json", "y.imai at ocaml.jp", "broken"}, "",
+			`{"input":"y.imai at ocaml.jp","address":"y.imai@ocaml.jp","confidence":1,"steps":["findTheAt"]}` + "\n" +
+				`{"input":"broken","error":"unable to fix email address: broken -> broken"}` + "\n", exitSomeFailed},
+		{[]string{"-format", "xml", "broken"}, "", "", exitUsage},
+		{[]string{"-f", filepath.Join(dir, "missing.txt")}, "", "", exitUsage},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		code := run(test.args, strings.NewReader(test.stdin), &stdout, &stderr)
+		if code != test.code {
+			t.Errorf("%v: expected exit code %d, Actual: %d (%s)", test.args, test.code, code, stderr.String())
+		}
+		if stdout.String() != test.expected {
+			t.Errorf("%v: Expected: %q, Actual: %q", test.args, test.expected, stdout.String())
+		}
+	}
+}
+
+func TestRunTrace(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-trace", "y.imai at ocaml.jp"}, strings.NewReader(""), &stdout, &stderr)
+	if code != exitOK {
+		t.Errorf("Expected exit code %d, Actual: %d", exitOK, code)
+	}
+	if !strings.Contains(stderr.String(), "Find at:       y.imai@ocaml.jp\n") {
+		t.Errorf("Expected a trace, Actual: %q", stderr.String())
+	}
+}
@@ -0,0 +1,32 @@
+// Command revealerd serves revealer over HTTP. See package server for
+// the endpoints.
+//
+// Usage:
+//
+//	revealerd [-addr :8080]
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dstroot/revealer/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "`address` to listen on")
+	flag.Parse()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(nil),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+	log.Printf("revealerd listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
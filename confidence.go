@@ -0,0 +1,113 @@
+package revealer
+
+import "strings"
+
+// Names of the guesses that lower a Result's Confidence.
+const (
+	// GuessLastHurrah means a lone "#" or "*" was taken for "@".
+	GuessLastHurrah = "lastHurrah"
+
+	// GuessProviderAt means an "@" was put in front of a provider,
+	// e.g. "dhananjay.patkar.gmail.com" or "dexgecko (gmail)".
+	GuessProviderAt = "providerAt"
+
+	// GuessSpaceAt means addDots took the only space for "@", as in
+	// "bernhard zq1.de".
+	GuessSpaceAt = "spaceAt"
+
+	// GuessTruncated means text after the domain was cut off.
+	GuessTruncated = "truncated"
+
+	// GuessSpacesDotted, GuessSpacesDropped and GuessTagKept are the
+	// readings only Candidates tries: every space in the name made a
+	// dot, every space dropped, and a "+tag" kept.
+	GuessSpacesDotted  = "spacesDotted"
+	GuessSpacesDropped = "spacesDropped"
+	GuessTagKept       = "tagKept"
+
+	// GuessTypo means correctTypos corrected the domain.
+	GuessTypo = "typo"
+
+	// GuessDecoded means the address was found by a decoder (see
+	// WithDecoders), and GuessDecodedSuffix that its domain is not a
+	// well known one, only under a common top-level domain.
+	GuessDecoded       = "decoded"
+	GuessDecodedSuffix = "decodedSuffix"
+)
+
+// guess is a rule that fires during a pipeline step when the result is
+// less certain. Each guess that fires multiplies the confidence by its
+// weight.
+type guess struct {
+	name   string
+	step   string
+	weight float64
+	fired  func(r *Revealer, before, after string) bool
+}
+
+var guesses = []guess{
+	{GuessLastHurrah, StepFindTheAt, 0.5, func(r *Revealer, before, after string) bool {
+		return r.at.Replace(before) != after
+	}},
+	{GuessProviderAt, StepGeneralFixes, 0.8, addsAt},
+	{GuessProviderAt, StepHandcraftedFixes, 0.8, addsAt},
+	{GuessSpaceAt, StepAddDots, 0.6, addsAt},
+	{GuessTypo, StepCorrectTypos, 0.7, func(_ *Revealer, before, after string) bool {
+		return true
+	}},
+	{GuessTruncated, StepHandcraftedFixes, 0.7, func(_ *Revealer, before, after string) bool {
+		return trimAfterDomain(before) != before
+	}},
+
+	// recorded by Candidates for the variant it tries
+	{GuessSpacesDotted, "", 0.7, nil},
+	{GuessSpacesDropped, "", 0.7, nil},
+	{GuessTagKept, "", 0.9, nil},
+
+	// recorded for an address a decoder found
+	{GuessDecoded, "", 0.8, nil},
+	{GuessDecodedSuffix, "", 0.6, nil},
+}
+
+// addsAt reports whether a step put in the first "@".
+func addsAt(_ *Revealer, before, after string) bool {
+	return !strings.Contains(before, "@") && strings.Contains(after, "@")
+}
+
+// guessStep records, once each, the guesses that fired while step
+// changed before into after.
+func (f *fix) guessStep(step, before, after string) {
+	for _, g := range guesses {
+		if g.step == step && g.fired(f.Revealer, before, after) && !f.guessedAlready(g.name) {
+			f.guessed = append(f.guessed, g.name)
+		}
+	}
+}
+
+func (f *fix) guessedAlready(name string) bool {
+	for _, g := range f.guessed {
+		if g == name {
+			return true
+		}
+	}
+	return false
+}
+
+// confidence is the product of the weights of the guesses made.
+func (f *fix) confidence() float64 {
+	c := 1.0
+	for _, name := range f.guessed {
+		c *= weight(name)
+	}
+	return c
+}
+
+// weight returns the weight of the named guess.
+func weight(name string) float64 {
+	for _, g := range guesses {
+		if g.name == name {
+			return g.weight
+		}
+	}
+	return 1
+}
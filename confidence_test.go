@@ -0,0 +1,37 @@
+package revealer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfidence(t *testing.T) {
+
+	var tests = []struct {
+		email      string
+		confidence float64
+		guesses    []string
+	}{
+		{"naranjo dot manuel at gmail dot com", 1, nil},
+		{"demirozali (@) gmail.com", 1, nil},
+		{"felix021 # gmail.com", 0.5, []string{GuessLastHurrah}},
+		{"dhananjay.patkar.gmail.com", 0.8, []string{GuessProviderAt}},
+		{"dexgecko (gmail)", 0.8, []string{GuessProviderAt}},
+		{"bernhard zq1.de", 0.6, []string{GuessSpaceAt}},
+		{"test7/@example.com.invalid", 0.7, []string{GuessTruncated}},
+	}
+
+	for _, test := range tests {
+		result, err := Reveal(test.email)
+		if err != nil {
+			t.Errorf("Error: %s", err)
+			continue
+		}
+		if result.Confidence != test.confidence {
+			t.Errorf("%s: expected confidence: %v, Actual: %v", test.email, test.confidence, result.Confidence)
+		}
+		if strings.Join(result.Guesses, ",") != strings.Join(test.guesses, ",") {
+			t.Errorf("%s: expected guesses: %v, Actual: %v", test.email, test.guesses, result.Guesses)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package revealer
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Names of the decoders, for use with WithDecoders.
+const (
+	// DecoderReverse reads the address backwards, as in
+	// "moc.liamg@nhoj", often shown with CSS "direction: rtl".
+	DecoderReverse = "reverse"
+
+	// DecoderROT13 undoes ROT13, as in "wbua@tznvy.pbz".
+	DecoderROT13 = "rot13"
+
+	// DecoderBase64 decodes the first base64 word, as in "decode me:
+	// am9obkBnbWFpbC5jb20=".
+	DecoderBase64 = "base64"
+)
+
+// decoders are tried in this order.
+var decoders = []struct {
+	name   string
+	decode func(email string) (string, bool)
+}{
+	{DecoderReverse, reverse},
+	{DecoderROT13, rot13},
+	{DecoderBase64, unbase64},
+}
+
+// decodedTLDs are the top-level domains a decoded address may have
+// without being one of the well known mail domains.
+var decodedTLDs = []string{"com", "net", "org", "edu", "gov", "mil", "int", "info"}
+
+// base64Word matches a word that may be base64.
+var base64Word = regexp.MustCompile(`[A-Za-z0-9+/_-]{8,}={0,2}`)
+
+// WithDecoders also tries the named decoders (see the Decoder
+// constants), or all of them if none are named, when an address does
+// not reveal one under a known public suffix. A decoded address is
+// only taken if it is valid and plausible: it spells out its "@"
+// rather than needing one guessed, and is at one of the providers or
+// DefaultTypoDomains, or else, with less confidence, registrable under
+// a common generic top-level domain such as ".com". Any random string
+// decodes to something under some country code, so that is not
+// enough. Result.Decoder says which decoder found it. Unknown names
+// are ignored.
+func WithDecoders(names ...string) Option {
+	return func(r *Revealer) {
+		r.decoders = nil
+		for _, d := range decoders {
+			if len(names) == 0 || contains(names, d.name) {
+				r.decoders = append(r.decoders, d.name)
+			}
+		}
+	}
+}
+
+// decode reveals email with the Revealer's decoders, after reveal
+// returned result and err for it as given.
+func (r *Revealer) decode(email string, v variant, t Tracer, result *Result, err error) (*Result, error) {
+	if len(r.decoders) == 0 || err == nil && knownDomain(result.Domain) {
+		return result, err
+	}
+	for _, d := range decoders {
+		if !contains(r.decoders, d.name) {
+			continue
+		}
+		decoded, ok := d.decode(email)
+		if !ok {
+			continue
+		}
+		dv := v
+		dv.decoder = d.name
+		dr, derr := r.revealPipeline(decoded, dv, t)
+		if derr != nil || contains(dr.Guesses, GuessProviderAt) || contains(dr.Guesses, GuessSpaceAt) {
+			continue // the "@" must be decoded, not made up
+		}
+		switch {
+		case r.mailDomain(dr.Domain):
+		case knownDomain(dr.Domain) && contains(decodedTLDs, dr.Domain[strings.LastIndex(dr.Domain, ".")+1:]):
+			dr.Guesses = append(dr.Guesses, GuessDecodedSuffix)
+			dr.Confidence *= weight(GuessDecodedSuffix)
+		default:
+			continue
+		}
+		dr.Input = email
+		return dr, nil
+	}
+	return result, err
+}
+
+// mailDomain reports whether domain is that of a provider or one of
+// DefaultTypoDomains.
+func (r *Revealer) mailDomain(domain string) bool {
+	for _, p := range r.providers {
+		if p.Domain == domain {
+			return true
+		}
+	}
+	return contains(DefaultTypoDomains, domain)
+}
+
+// reverse returns email backwards.
+func reverse(email string) (string, bool) {
+	runes := []rune(strings.TrimSpace(email))
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes), len(runes) > 1
+}
+
+// rot13 rotates the ASCII letters of email by 13.
+func rot13(email string) (string, bool) {
+	letters := false
+	decoded := strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z':
+			letters = true
+			return 'a' + (r-'a'+13)%26
+		case 'A' <= r && r <= 'Z':
+			letters = true
+			return 'A' + (r-'A'+13)%26
+		}
+		return r
+	}, email)
+	return decoded, letters
+}
+
+// unbase64 decodes the first word of email that is base64 of printable
+// text, padded or not, in the standard or URL alphabet.
+func unbase64(email string) (string, bool) {
+	encodings := []*base64.Encoding{
+		base64.StdEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.RawURLEncoding,
+	}
+	for _, word := range base64Word.FindAllString(email, -1) {
+		for _, enc := range encodings {
+			b, err := enc.DecodeString(word)
+			if err == nil && isPrintable(b) {
+				return string(b), true
+			}
+		}
+	}
+	return "", false
+}
+
+// isPrintable reports whether b is non-empty UTF-8 text with no
+// control characters.
+func isPrintable(b []byte) bool {
+	if len(b) == 0 || !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
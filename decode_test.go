@@ -0,0 +1,101 @@
+package revealer
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecoders(t *testing.T) {
+
+	var tests = []struct {
+		email      string
+		expected   string
+		decoder    string
+		confidence float64
+	}{
+		{"moc.liamg@nhoj", "john@gmail.com", DecoderReverse, 0.8},
+		{"moc tod elpmaxe ta nhoj", "john@example.com", DecoderReverse, 0.48},
+		{"wbua@tznvy.pbz", "john@gmail.com", DecoderROT13, 0.8},
+		{"WBUA NG RKNZCYR QBG PBZ", "john@example.com", DecoderROT13, 0.48},
+		{"decode me: am9obkBnbWFpbC5jb20=", "john@gmail.com", DecoderBase64, 0.8},
+		{"am9obkBnbWFpbC5jb20", "john@gmail.com", DecoderBase64, 0.8},
+		{"john at example dot com", "john@example.com", "", 1},
+		{"dexgecko (gmail)", "dexgecko@gmail.com", "", 0.8},
+
+		// random input decodes to some country code, which is not enough
+		{"ann@nop.pz", "ann@nop.pz", "", 1},
+		{"ann@foo.bar.qr", "ann@foo.bar.qr", "", 1},
+	}
+
+	r := New(WithDecoders())
+	for _, test := range tests {
+		result, err := r.Reveal(test.email)
+		if err != nil {
+			t.Errorf("%s: Error: %s", test.email, err)
+			continue
+		}
+		if result.Address != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, result.Address)
+		}
+		if result.Decoder != test.decoder {
+			t.Errorf("%s: expected decoder %q, Actual: %q", test.email, test.decoder, result.Decoder)
+		}
+		if result.Input != test.email {
+			t.Errorf("Expected: %s, Actual: %s", test.email, result.Input)
+		}
+		if fmt.Sprintf("%.2f", result.Confidence) != fmt.Sprintf("%.2f", test.confidence) {
+			t.Errorf("%s: expected confidence %.2f, Actual: %.2f %v", test.email, test.confidence, result.Confidence, result.Guesses)
+		}
+		if test.decoder != "" && !contains(result.Guesses, GuessDecoded) {
+			t.Errorf("%s: expected guess %s, Actual: %v", test.email, GuessDecoded, result.Guesses)
+		}
+	}
+
+	// decoders are off by default
+	if result, _ := Fix("moc.liamg@nhoj"); result != "moc.liamg@nhoj" {
+		t.Errorf("Expected: moc.liamg@nhoj, Actual: %s", result)
+	}
+
+	// only the named decoders run
+	if result, _ := New(WithDecoders(DecoderROT13)).Fix("moc.liamg@nhoj"); result != "moc.liamg@nhoj" {
+		t.Errorf("Expected: moc.liamg@nhoj, Actual: %s", result)
+	}
+
+	// an implausible decoding is not taken
+	if result, _ := r.Fix("hello@world"); result != "hello@world" {
+		t.Errorf("Expected: hello@world, Actual: %s", result)
+	}
+
+	// nor one whose "@" had to be guessed, as "moc@qq.com" for "qq com"
+	for _, email := range []string{"qq.com", "qq com", "moc.liamg nhoj"} {
+		if result, err := r.Reveal(email); err == nil && result.Decoder != "" {
+			t.Errorf("%s: expected no decoder, Actual: %s by %q", email, result.Address, result.Decoder)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+
+	var tests = []struct {
+		decode   func(string) (string, bool)
+		input    string
+		expected string
+		ok       bool
+	}{
+		{reverse, " moc.liamg@nhoj ", "john@gmail.com", true},
+		{reverse, "a", "a", false},
+		{rot13, "Wbua@tznvy.pbz", "John@gmail.com", true},
+		{rot13, "123@456", "123@456", false},
+		{unbase64, "see am9obkBnbWFpbC5jb20=", "john@gmail.com", true},
+		{unbase64, "am9obkBnbWFpbC5jb20", "john@gmail.com", true},
+		{unbase64, "john at example dot com", "", false},
+		{unbase64, "AAECAwQFBgc=", "", false},
+	}
+
+	for _, test := range tests {
+		actual, ok := test.decode(test.input)
+		if actual != test.expected || ok != test.ok {
+			t.Errorf("Expected: %s %t, Actual: %s %t", test.expected, test.ok, actual, ok)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package revealer
+
+import "errors"
+
+var (
+	// ErrEmpty is returned for an empty email address.
+	ErrEmpty = errors.New("email address cannot be empty")
+
+	// ErrUnfixable matches, with errors.Is, every *FixError.
+	ErrUnfixable = errors.New("unable to fix email address")
+
+	// ErrNotStrict is the FixError.Err when an address parses but
+	// fails ValidateStrict.
+	ErrNotStrict = errors.New("not a bare address under a known public suffix")
+
+	// ErrIDN matches, with errors.Is, the FixError.Err when an address
+	// parses but its domain breaks the IDNA rules, e.g. "xn--zz".
+	ErrIDN = errors.New("not a valid internationalized domain name")
+)
+
+// FixError is returned when no valid address could be revealed.
+type FixError struct {
+	// Input is the address as given.
+	Input string
+
+	// Last is the string the pipeline ended with.
+	Last string
+
+	// Stage is the last pipeline step that changed the address (see
+	// the Step constants), or "" if none did.
+	Stage string
+
+	// Err is why Last is not valid, usually from net/mail.
+	Err error
+}
+
+func (e *FixError) Error() string {
+	return ErrUnfixable.Error() + ": " + e.Input + " -> " + e.Last
+}
+
+// Unwrap returns the validation error.
+func (e *FixError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every FixError match ErrUnfixable.
+func (e *FixError) Is(target error) bool {
+	return target == ErrUnfixable
+}
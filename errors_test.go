@@ -0,0 +1,48 @@
+package revealer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+
+	if _, err := Fix(""); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected Err: %s, Actual Err: %v", ErrEmpty, err)
+	}
+
+	var tests = []struct {
+		revealer *Revealer
+		email    string
+		last     string
+		stage    string
+		strict   bool
+	}{
+		{New(), "broken", "broken", "", false},
+		{New(), "test [at]", "test@", StepAddDots, false},
+		{New(WithValidation(ValidateStrict)), "test at localhost", "test@localhost", StepFindTheAt, true},
+	}
+
+	for _, test := range tests {
+		_, err := test.revealer.Fix(test.email)
+		if !errors.Is(err, ErrUnfixable) {
+			t.Errorf("%s: expected ErrUnfixable, Actual Err: %v", test.email, err)
+		}
+
+		var fixErr *FixError
+		if !errors.As(err, &fixErr) {
+			t.Errorf("%s: expected a *FixError, Actual Err: %v", test.email, err)
+			continue
+		}
+		if fixErr.Input != test.email || fixErr.Last != test.last || fixErr.Stage != test.stage {
+			t.Errorf("Expected: %s %s %s, Actual: %s %s %s", test.email, test.last, test.stage,
+				fixErr.Input, fixErr.Last, fixErr.Stage)
+		}
+		if fixErr.Err == nil || errors.Unwrap(err) != fixErr.Err {
+			t.Errorf("%s: expected the validation error to be wrapped", test.email)
+		}
+		if errors.Is(err, ErrNotStrict) != test.strict {
+			t.Errorf("%s: expected ErrNotStrict: %t, Actual Err: %v", test.email, test.strict, fixErr.Err)
+		}
+	}
+}
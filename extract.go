@@ -0,0 +1,283 @@
+package revealer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match is an obfuscated address found in free text.
+type Match struct {
+	// Start and End are the byte offsets of Text in the text searched.
+	Start int
+	End   int
+
+	// Text is the obfuscated span, text[Start:End].
+	Text string
+
+	// Result is the revealed address.
+	*Result
+}
+
+// notLocal are words that come before a bare "at" in prose, as in
+// "hosted at github.com", and so are not taken as a name.
+var notLocal = map[string]bool{
+	"a": true, "am": true, "are": true, "available": true, "based": true,
+	"be": true, "been": true, "find": true, "found": true, "here": true,
+	"hosted": true, "is": true, "it": true, "live": true, "lives": true,
+	"located": true, "look": true, "me": true, "online": true, "or": true,
+	"published": true, "running": true, "see": true, "that": true,
+	"there": true, "this": true, "us": true, "was": true, "were": true,
+}
+
+// token is a run of non-space text.
+type token struct {
+	start, end int
+	text       string
+}
+
+// Extract finds obfuscated addresses in text using the default
+// Revealer. See Revealer.Extract.
+func Extract(text string) []Match {
+	return defaultRevealer.Extract(text)
+}
+
+// Extract finds obfuscated addresses in free text, such as a README or
+// a forum post, and reveals each of them. A span is a name and a
+// domain around an "@" or a spelling of one ("at", "[at]", "-at-",
+// ...), with the domain's dots possibly spelled out too. Only spans
+// that reveal to an address with a dotted domain are returned, in the
+// order they appear.
+func (r *Revealer) Extract(text string) []Match {
+	var (
+		matches []Match
+		toks    = tokenize(text)
+		next    = 0 // first token not yet part of a match
+	)
+	for i := range toks {
+		if i < next {
+			continue
+		}
+		first, last, ok := r.findSpan(toks, i)
+		if !ok || first < next {
+			continue
+		}
+
+		start, end := trimSpan(text, toks[first].start, toks[last].end)
+		result, err := r.Reveal(text[start:end])
+		if err != nil || !strings.Contains(result.Domain, ".") {
+			continue
+		}
+		matches = append(matches, Match{
+			Start:  start,
+			End:    end,
+			Text:   text[start:end],
+			Result: result,
+		})
+		next = last + 1
+	}
+	return matches
+}
+
+// tokenize splits text into runs of non-space.
+func tokenize(text string) []token {
+	var toks []token
+	start := -1
+	for i, c := range text {
+		if unicode.IsSpace(c) {
+			if start >= 0 {
+				toks = append(toks, token{start, i, text[start:i]})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		toks = append(toks, token{start, len(text), text[start:]})
+	}
+	return toks
+}
+
+// findSpan returns the first and last tokens of an address anchored
+// on toks[i], either a spelling of "@" on its own or a word with one
+// inside it.
+func (r *Revealer) findSpan(toks []token, i int) (first, last int, ok bool) {
+	if r.isAt(toks[i].text) {
+		// a spelling of "@" in loose brackets, as in "[ at ]"
+		a, b := i, i
+		if a > 0 && b+1 < len(toks) && isBracket(toks[a-1].text) && isBracket(toks[b+1].text) {
+			a, b = a-1, b+1
+		}
+		if a == 0 || b+1 == len(toks) || !isWord(toks[a-1].text) || !isWord(toks[b+1].text) {
+			return 0, 0, false
+		}
+		if strings.ToLower(toks[i].text) == "at" && notLocal[strings.ToLower(trimWord(toks[a-1].text))] {
+			return 0, 0, false
+		}
+		return r.extendLeft(toks, a-1), r.extendRight(toks, b+1), true
+	}
+
+	switch at := r.atIndex(toks[i].text); {
+	case at == atInside:
+		return r.extendLeft(toks, i), r.extendRight(toks, i), true
+	case at == atStart && i > 0 && isWord(toks[i-1].text):
+		return r.extendLeft(toks, i-1), r.extendRight(toks, i), true
+	case at == atEnd && i+1 < len(toks) && isWord(toks[i+1].text):
+		return r.extendLeft(toks, i), r.extendRight(toks, i+1), true
+	}
+	return 0, 0, false
+}
+
+// extendLeft takes in "dot"-joined words before toks[j], as in
+// "naranjo dot manuel at ...", and a "+ tag", as in "ev45ive + github".
+func (r *Revealer) extendLeft(toks []token, j int) int {
+	for j >= 2 && (r.isDot(toks[j-1].text) || toks[j-1].text == "+") && isWord(toks[j-2].text) {
+		j -= 2
+	}
+	return j
+}
+
+// extendRight takes in "dot"-joined words after toks[j], as in
+// "... at gmail dot com", and words starting with a dot, as in
+// "digicyc@gmail .com".
+func (r *Revealer) extendRight(toks []token, j int) int {
+	for {
+		switch {
+		case j+2 < len(toks) && r.isDot(toks[j+1].text) && isWord(toks[j+2].text):
+			j += 2
+		case j+1 < len(toks) && strings.HasPrefix(toks[j+1].text, ".") && isWord(toks[j+1].text[1:]):
+			j++
+		default:
+			return j
+		}
+	}
+}
+
+// markerTrim is the punctuation around a spelling of "@" or ".", as in
+// "(_at_)" or "[(dot)]".
+const markerTrim = " ()[]{}<>_-.'\""
+
+// isAt reports whether s on its own is a spelling of "@".
+func (r *Revealer) isAt(s string) bool {
+	return strings.Trim(r.findAt(s), markerTrim) == "@"
+}
+
+// isDot reports whether s on its own is a spelling of ".".
+func (r *Revealer) isDot(s string) bool {
+	d := r.dots.Replace(r.general.Replace(" " + strings.ToLower(s) + " "))
+	return strings.Contains(d, ".") && strings.Trim(d, markerTrim) == ""
+}
+
+// findAt runs the spellings of "@", but not the guesses, over s.
+func (r *Revealer) findAt(s string) string {
+	s = r.general.Replace(" " + strings.ToLower(s) + " ")
+	return r.at.Replace(r.at.Replace(s))
+}
+
+// Where atIndex finds a spelling of "@" in a word.
+const (
+	atNone = iota
+	atStart
+	atInside
+	atEnd
+)
+
+// atIndex says where in s a spelling of "@" is, if anywhere, as in
+// "(@gmail.com)" or "zxytim[at]gmail[dot]com".
+func (r *Revealer) atIndex(s string) int {
+	s = strings.Trim(r.findAt(s), markerTrim)
+	at := strings.Index(s, "@")
+	switch {
+	case at < 0 || s == "@":
+		return atNone
+	case at == 0:
+		return atStart
+	case at == len(s)-1:
+		return atEnd
+	}
+	return atInside
+}
+
+// isBracket reports whether s is only brackets, as in "[" or "((".
+func isBracket(s string) bool {
+	return s != "" && strings.Trim(s, "()[]{}<>") == ""
+}
+
+// isWord reports whether s could be part of a name or domain.
+func isWord(s string) bool {
+	s = trimWord(s)
+	if s == "" {
+		return false
+	}
+	letters := false
+	for _, c := range s {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			letters = true
+		case strings.ContainsRune("._-+", c):
+		default:
+			return false
+		}
+	}
+	return letters
+}
+
+// trimWord trims the punctuation prose puts around a word.
+func trimWord(s string) string {
+	return strings.Trim(s, `()[]{}<>"',;:!?`)
+}
+
+// trimSpan trims sentence punctuation, and brackets and quotes that
+// are not closed inside the span, from either end of text[start:end].
+func trimSpan(text string, start, end int) (int, int) {
+	const (
+		openers = "([{<\"'"
+		closers = ")]}>\"'"
+	)
+	for start < end {
+		c, size := utf8.DecodeRuneInString(text[start:end])
+		k := strings.IndexRune(openers, c)
+		if k < 0 || !unbalanced(text[start:end], c, rune(closers[k])) {
+			break
+		}
+		start += size
+	}
+	for start < end {
+		c, size := utf8.DecodeLastRuneInString(text[start:end])
+		if strings.ContainsRune(".,;:!?", c) {
+			end -= size
+			continue
+		}
+		k := strings.IndexRune(closers, c)
+		if k < 0 || !unbalanced(text[start:end], c, rune(openers[k])) {
+			break
+		}
+		end -= size
+	}
+
+	// a bracket around the whole span, as in "(x at y dot com)"
+	for end-start > 2 {
+		k := strings.IndexByte(openers, text[start])
+		inner := text[start+1 : end-1]
+		if k < 0 || text[end-1] != closers[k] ||
+			openers[k] == closers[k] && strings.IndexByte(inner, openers[k]) >= 0 ||
+			unbalanced(inner, rune(openers[k]), rune(closers[k])) ||
+			unbalanced(inner, rune(closers[k]), rune(openers[k])) {
+			break
+		}
+		start++
+		end--
+	}
+	return start, end
+}
+
+// unbalanced reports whether span has more of c than of its partner,
+// or an odd number of c when c is its own partner, like a quote.
+func unbalanced(span string, c, partner rune) bool {
+	n := strings.Count(span, string(c))
+	if c == partner {
+		return n%2 == 1
+	}
+	return n > strings.Count(span, string(partner))
+}
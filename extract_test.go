@@ -0,0 +1,58 @@
+package revealer
+
+import "testing"
+
+func TestExtract(t *testing.T) {
+
+	var tests = []struct {
+		text     string
+		spans    []string
+		expected []string
+	}{
+		{"Contact naranjo dot manuel at gmail dot com for details.",
+			[]string{"naranjo dot manuel at gmail dot com"},
+			[]string{"naranjo.manuel@gmail.com"}},
+		{"Bugs to zxytim[at]gmail[dot]com, thanks",
+			[]string{"zxytim[at]gmail[dot]com"},
+			[]string{"zxytim@gmail.com"}},
+		{"Maintainer (dima -at- secretsauce -dot- net)",
+			[]string{"dima -at- secretsauce -dot- net"},
+			[]string{"dima@secretsauce.net"}},
+		{"email: y.imai at ocaml.jp.",
+			[]string{"y.imai at ocaml.jp"},
+			[]string{"y.imai@ocaml.jp"}},
+		{"Write to foo@bar.com or defagos (@) gmail (.) com\nBye",
+			[]string{"foo@bar.com", "defagos (@) gmail (.) com"},
+			[]string{"foo@bar.com", "defagos@gmail.com"}},
+		{"Prénom : digicyc@gmail .com",
+			[]string{"digicyc@gmail .com"},
+			[]string{"digicyc@gmail.com"}},
+		{"Thanks to \"types\" at \"ccs.neu.edu\"!",
+			[]string{"\"types\" at \"ccs.neu.edu\""},
+			[]string{"types@ccs.neu.edu"}},
+		{"Ask elias ((at)) showk ((dot)) me or ideaplexus [ at ] gmail today",
+			[]string{"elias ((at)) showk ((dot)) me", "ideaplexus [ at ] gmail"},
+			[]string{"elias@showk.me", "ideaplexus@gmail.com"}},
+		{"By gentimouton (@gmail.com) and ev45ive + github @gmail.com",
+			[]string{"gentimouton (@gmail.com)", "ev45ive + github @gmail.com"},
+			[]string{"gentimouton@gmail.com", "ev45ive@gmail.com"}},
+		{"The code is hosted at github.com and I am at home.", nil, nil},
+		{"No addresses here at all", nil, nil},
+	}
+
+	for _, test := range tests {
+		matches := Extract(test.text)
+		if len(matches) != len(test.expected) {
+			t.Errorf("%s: expected %d matches, Actual: %d", test.text, len(test.expected), len(matches))
+			continue
+		}
+		for i, m := range matches {
+			if m.Text != test.spans[i] || test.text[m.Start:m.End] != m.Text {
+				t.Errorf("Expected span: %q, Actual: %q at %d:%d", test.spans[i], m.Text, m.Start, m.End)
+			}
+			if m.Address != test.expected[i] {
+				t.Errorf("Expected: %s, Actual: %s", test.expected[i], m.Address)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package revealer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// acePrefix marks a label in punycode.
+const acePrefix = "xn--"
+
+// idnaProfile maps Unicode labels as UTS #46 does for lookup, folding
+// case and composing them in NFC, and checks them against IDNA 2008.
+var idnaProfile = idna.New(idna.MapForLookup(), idna.BidiRule())
+
+// toASCII returns domain with each Unicode label in punycode, e.g.
+// "münchen.de" becomes "xn--mnchen-3ya.de".
+func toASCII(domain string) (string, error) {
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		encoded, err := idnaProfile.ToASCII(label)
+		if err != nil {
+			return "", err
+		}
+		labels[i] = encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// toUnicode returns domain with each punycode label decoded, e.g.
+// "xn--mnchen-3ya.de" becomes "münchen.de", and each Unicode label
+// mapped as for lookup.
+func toUnicode(domain string) (string, error) {
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		if isASCII(label) && !strings.HasPrefix(label, acePrefix) {
+			continue
+		}
+		decoded, err := idnaProfile.ToUnicode(label)
+		if err != nil {
+			return "", err
+		}
+		labels[i] = decoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// checkIDN returns why domain is not a valid internationalized domain
+// name, or nil. All-ASCII labels other than punycode ones are left to
+// net/mail. Unicode labels are mapped and checked by idnaProfile, so a
+// decomposed "ü" is taken as the composed one, and must then also be
+// letters, marks, digits and hyphens only, with no hyphens in the third
+// and fourth places, and at most 63 bytes once in punycode.
+func checkIDN(domain string) error {
+	for _, label := range strings.Split(domain, ".") {
+		if isASCII(label) && !strings.HasPrefix(label, acePrefix) {
+			continue
+		}
+		decoded, err := idnaProfile.ToUnicode(label)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrIDN, err)
+		}
+		if isASCII(decoded) {
+			return fmt.Errorf("%w: %q is not valid punycode", ErrIDN, label)
+		}
+		if err := checkLabel(decoded); err != nil {
+			return fmt.Errorf("%w: %q %s", ErrIDN, label, err)
+		}
+	}
+
+	ascii, err := toASCII(domain)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrIDN, err)
+	}
+	for _, label := range strings.Split(ascii, ".") {
+		if len(label) > 63 {
+			return fmt.Errorf("%w: %q is longer than 63 bytes", ErrIDN, label)
+		}
+	}
+	if len(ascii) > 253 {
+		return fmt.Errorf("%w: longer than 253 bytes", ErrIDN)
+	}
+	return nil
+}
+
+// checkLabel returns why the Unicode label breaks the IDNA rules, or
+// nil.
+func checkLabel(label string) error {
+	if label == "" {
+		return errors.New("is empty")
+	}
+	if first, _ := utf8.DecodeRuneInString(label); unicode.IsMark(first) {
+		return errors.New("starts with a combining mark")
+	}
+	if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+		return errors.New("starts or ends with a hyphen")
+	}
+	if runes := []rune(label); len(runes) >= 4 && runes[2] == '-' && runes[3] == '-' {
+		return errors.New("has hyphens in the third and fourth places")
+	}
+	for _, r := range label {
+		if r != '-' && !unicode.IsLetter(r) && !unicode.IsMark(r) && !unicode.IsDigit(r) {
+			return fmt.Errorf("has %q", r)
+		}
+	}
+	return nil
+}
+
+// isASCII reports whether s is all ASCII.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package revealer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckIDN(t *testing.T) {
+
+	var tests = []struct {
+		domain string
+		valid  bool
+	}{
+		{"example.com", true},
+		{"ab--cd.de", true},
+		{"münchen.de", true},
+		{"xn--mnchen-3ya.de", true},
+		{"mu\u0308nchen.de", true},
+		{"xn--munchen-gie.de", false},
+		{"пример.рф", true},
+		{"भारत.भारत", true},
+		{"xn--zz.de", false},
+		{"xn--abc-.de", false},
+		{"-münchen.de", false},
+		{"münchen-.de", false},
+		{"mü--nchen.de", false},
+		{"́ab.de", false},
+		{"mün☃chen.de", false},
+		{strings.Repeat("ü", 60) + ".de", false},
+	}
+
+	for _, test := range tests {
+		err := checkIDN(test.domain)
+		if (err == nil) != test.valid {
+			t.Errorf("%s: expected valid: %t, Actual: %v", test.domain, test.valid, err)
+		}
+		if err != nil && !errors.Is(err, ErrIDN) {
+			t.Errorf("%s: expected ErrIDN, Actual: %v", test.domain, err)
+		}
+	}
+}
+
+func TestRevealIDN(t *testing.T) {
+
+	var tests = []struct {
+		email   string
+		address string
+		ascii   string
+		unicode string
+	}{
+		{"user at münchen dot de", "user@münchen.de", "xn--mnchen-3ya.de", "münchen.de"},
+		{"user@xn--mnchen-3ya.de", "user@xn--mnchen-3ya.de", "xn--mnchen-3ya.de", "münchen.de"},
+		{"jane at 例子 dot 中国", "jane@例子.中国", "xn--fsqu00a.xn--fiqs8s", "例子.中国"},
+		{"user at bücher.de and more", "user@bücher.de", "xn--bcher-kva.de", "bücher.de"},
+		{"user at mu\u0308nchen dot de", "user@münchen.de", "xn--mnchen-3ya.de", "münchen.de"},
+		{"user at example dot com", "user@example.com", "", ""},
+	}
+
+	r := New(WithValidation(ValidateStrict))
+	for _, test := range tests {
+		result, err := r.Reveal(test.email)
+		if err != nil {
+			t.Errorf("%s: Error: %s", test.email, err)
+			continue
+		}
+		if result.Address != test.address {
+			t.Errorf("Expected: %s, Actual: %s", test.address, result.Address)
+		}
+		if result.DomainASCII != test.ascii || result.DomainUnicode != test.unicode {
+			t.Errorf("Expected: %s and %s, Actual: %s and %s", test.ascii, test.unicode, result.DomainASCII, result.DomainUnicode)
+		}
+	}
+
+	if _, err := Fix("user@xn--zz.de"); !errors.Is(err, ErrIDN) {
+		t.Errorf("Expected: ErrIDN, Actual: %v", err)
+	}
+}
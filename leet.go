@@ -0,0 +1,192 @@
+package revealer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// leet maps each leetspeak character to the letters it may stand for.
+var leet = map[rune]string{
+	'0': "o",
+	'1': "il",
+	'3': "e",
+	'4': "a",
+	'5': "s",
+	'6': "g",
+	'7': "t",
+	'8': "b",
+	'9': "g",
+	'@': "a",
+	'+': "t",
+	'(': "c",
+	'$': "s",
+	'|': "li",
+	'!': "i",
+}
+
+// leetWords are the marker words unleet looks for besides the tokens
+// and domain labels of the providers.
+var leetWords = []string{"at", "dot", "com", "net", "org", "edu", "info"}
+
+// leetTLDs are the leetWords that are top-level domains.
+var leetTLDs = leetWords[2:]
+
+// maxLeetWord is the longest token unleet tries to read.
+const maxLeetWord = 12
+
+// markers returns the words unleet looks for.
+func (r *Revealer) markers() map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range leetWords {
+		words[w] = true
+	}
+	for _, p := range r.providers {
+		for _, t := range p.tokens() {
+			words[t] = true
+		}
+		for _, label := range strings.Split(p.Domain, ".") {
+			words[label] = true
+		}
+	}
+	return words
+}
+
+// unleet reads marker words written in leetspeak, such as "@+" or "4t"
+// for "at", "d07" for "dot", "(0m" for "com" and "9mail" or "gm4il" for
+// "gmail". Only words standing on their own, between spaces, brackets
+// or the ends of email, are read: a label next to a "." or "@", as in
+// "john@n3t.com", is part of a real address. The local part, every
+// word before the first "@" or "at", is left alone as its digits are
+// most likely real, and once there is an "@" no other word is read as
+// "at". A top-level domain is only read in the last word, so that the
+// "c0m" of "h4x0r @ c0m dot org" stays a name.
+func (r *Revealer) unleet(email string) string {
+	if !strings.ContainsAny(email, "0123456789@+($|!") {
+		return email
+	}
+
+	var (
+		b      strings.Builder
+		atSeen bool
+		first  = true
+		start  = -1
+	)
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		word, alone := email[start:end], standsAlone(email, start, end)
+		last := strings.IndexFunc(email[end:], isWordRune) < 0
+		start = -1
+		switch {
+		case first:
+			first = false
+			b.WriteString(word)
+		case alone:
+			b.WriteString(r.unleetWord(word, last, &atSeen))
+		default:
+			if word[0] == '@' {
+				atSeen = true
+			}
+			b.WriteString(word)
+		}
+	}
+	for i, c := range email {
+		switch {
+		case start >= 0 && (isWordRune(c) || strings.ContainsRune("+$|!", c)):
+		case start < 0 && (isWordRune(c) || strings.ContainsRune("@+($|!", c)):
+			start = i
+		default:
+			flush(i)
+			if c == '@' {
+				atSeen = true
+			}
+			b.WriteRune(c)
+		}
+	}
+	flush(len(email))
+	return b.String()
+}
+
+// standsAlone reports whether email[start:end] has a space, a bracket
+// or the end of email on both sides.
+func standsAlone(email string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(email[:start])
+	after, _ := utf8.DecodeRuneInString(email[end:])
+	return (start == 0 || unicode.IsSpace(before) || strings.ContainsRune("([{<", before)) &&
+		(end == len(email) || unicode.IsSpace(after) || strings.ContainsRune(")]}>", after))
+}
+
+// unleetWord returns the marker word that word is written for, or word.
+// A top-level domain is only read in the last word.
+func (r *Revealer) unleetWord(word string, last bool, atSeen *bool) string {
+	marker, prefix := r.markerFor(word), ""
+
+	// a leading "@" not part of the word is an "@", and "(" a bracket
+	if marker == "" && (word[0] == '@' || word[0] == '(') {
+		prefix, word = word[:1], word[1:]
+		if prefix == "@" {
+			*atSeen = true
+		}
+		marker = r.markerFor(word)
+	}
+
+	switch {
+	case !*atSeen:
+		if marker == "at" || word == "at" {
+			*atSeen = true
+			return prefix + "at"
+		}
+	case marker != "" && marker != "at" && (last || !r.tldMarker(marker)):
+		return prefix + marker
+	}
+	return prefix + word
+}
+
+// tldMarker reports whether marker is a top-level domain.
+func (r *Revealer) tldMarker(marker string) bool {
+	for _, p := range r.providers {
+		if p.Domain[strings.LastIndex(p.Domain, ".")+1:] == marker {
+			return true
+		}
+	}
+	return contains(leetTLDs, marker)
+}
+
+// markerFor returns the marker word word reads as with some leetspeak,
+// or "".
+func (r *Revealer) markerFor(word string) string {
+	if word == "" || len(word) > maxLeetWord || !strings.ContainsAny(word, "0123456789@+($|!") {
+		return ""
+	}
+	options := make([]string, 0, len(word))
+	for _, c := range word {
+		switch {
+		case 'a' <= c && c <= 'z':
+			options = append(options, string(c))
+		case leet[c] != "":
+			options = append(options, leet[c])
+		default:
+			return ""
+		}
+	}
+	return r.readAs(options, "")
+}
+
+// readAs returns the first marker word spelled by prefix followed by a
+// letter from each of options in turn, or "".
+func (r *Revealer) readAs(options []string, prefix string) string {
+	if len(options) == 0 {
+		if r.leetWords[prefix] {
+			return prefix
+		}
+		return ""
+	}
+	for _, c := range options[0] {
+		if m := r.readAs(options[1:], prefix+string(c)); m != "" {
+			return m
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package revealer
+
+import (
+	"testing"
+)
+
+func TestUnleet(t *testing.T) {
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{"john @+ gmail d07 com", "john at gmail dot com"},
+		{"john at 9mail (0m", "john at gmail com"},
+		{"john 4t gm4il d0t c0m", "john at gmail dot com"},
+		{"john (4t) gm41l (d0t) c0m", "john (at) gmail (dot) com"},
+		{"john [@+] gmail [d07] com", "john [at] gmail [dot] com"},
+		{"l33t 4t example d0t n3t", "l33t at example dot net"},
+
+		// a top-level domain is only read in the last word
+		{"h4x0r @ c0m dot org", "h4x0r @ c0m dot org"},
+		{"john at (0m dot org", "john at (0m dot org"},
+		{"john at n3t d0t c0m", "john at n3t dot com"},
+
+		// the local part keeps its digits
+		{"d07 at example dot com", "d07 at example dot com"},
+		{"j0hn d07 4t example d0t c0m", "j0hn d07 at example dot com"},
+		{"4t 4t example.com", "4t at example.com"},
+		{"h4ck3r@example.com", "h4ck3r@example.com"},
+		{"john.doe1979@gmail.com", "john.doe1979@gmail.com"},
+
+		// as do the labels of an address that has its "@" and dots
+		{"john@n3t.com", "john@n3t.com"},
+		{"jo@0rg.io", "jo@0rg.io"},
+		{"john@c0m.org", "john@c0m.org"},
+		{"bob@9mail.com", "bob@9mail.com"},
+		{"sales@6mail.cn", "sales@6mail.cn"},
+		{"john@1nfo.com", "john@1nfo.com"},
+		{"bob@d07.com", "bob@d07.com"},
+		{"john@gm4il.c0m", "john@gm4il.c0m"},
+		{"john at 9mail.(0m", "john at 9mail.(0m"},
+		{"j0hn.d07 4t example d0t c0m", "j0hn.d07 at example dot com"},
+
+		// and domains that only look like leetspeak
+		{"john@a7.com", "john@a7.com"},
+		{"john at 163 dot com", "john at 163 dot com"},
+		{"john at g00gle dot com", "john at g00gle dot com"},
+	}
+
+	r := New()
+	for _, test := range tests {
+		actual := r.unleet(test.input)
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+}
+
+func TestRevealLeet(t *testing.T) {
+
+	var tests = []struct {
+		email    string
+		expected string
+	}{
+		{"john @+ gmail d07 com", "john@gmail.com"},
+		{"john at 9mail (0m", "john@gmail.com"},
+		{"john (4t) gm41l (d0t) c0m", "john@gmail.com"},
+		{"j0hn.d07 4t example d0t c0m", "j0hn.d07@example.com"},
+		{"user 4t gm41l d0t c0m", "user@gmail.com"},
+		{"h4x0r @ c0m dot org", "h4x0r@c0m.org"},
+	}
+
+	for _, test := range tests {
+		actual, err := Fix(test.email)
+		if err != nil {
+			t.Errorf("%s: Error: %s", test.email, err)
+		}
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+
+	// provider names come from the Revealer's providers
+	r := New(WithProviders("yandex.ru"))
+	if actual, _ := r.Fix("ivan at y4nd3x dot ru"); actual != "ivan@yandex.ru" {
+		t.Errorf("Expected: ivan@yandex.ru, Actual: %s", actual)
+	}
+}
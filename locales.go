@@ -0,0 +1,122 @@
+package revealer
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Locales returns the codes of the locale packs, e.g. "de" or "ja".
+func Locales() []string {
+	entries, _ := localeFiles.ReadDir("locales")
+	var codes []string
+	for _, e := range entries {
+		codes = append(codes, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// LocaleRules returns the rules of a locale pack, such as German
+// "punkt" for ".", in the format of DefaultRules.
+func LocaleRules(code string) (*Rules, error) {
+	f, err := localeFiles.Open(path.Join("locales", strings.ToLower(code)+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("no locale %q", code)
+	}
+	defer f.Close()
+	return LoadRules(f)
+}
+
+// WithLocales adds the spellings of "@" and "." of the given locale
+// packs (see Locales) to the rules of every call, e.g. WithLocales("de",
+// "fr") finds "punkt" and "arobase". Use InLocale to pick them per
+// call instead. Unknown codes are ignored.
+func WithLocales(codes ...string) Option {
+	return func(r *Revealer) {
+		r.locales = append(r.locales, codes...)
+	}
+}
+
+// localeCache holds the Revealers InLocale has built, by locale set.
+type localeCache struct {
+	sync.Mutex
+	revealers map[string]*Revealer
+}
+
+// InLocale returns the default Revealer with the given locale packs
+// switched on. See Revealer.InLocale.
+func InLocale(codes ...string) *Revealer {
+	return defaultRevealer.InLocale(codes...)
+}
+
+// InLocale returns r with the given locale packs switched on as well,
+// for one call or many, e.g. r.InLocale("de").Fix(email). The rules for
+// each set of locales are only built the first time it is asked for.
+// Unknown codes are ignored.
+func (r *Revealer) InLocale(codes ...string) *Revealer {
+	locales := localeSet(append(append([]string(nil), r.locales...), codes...))
+	key := strings.Join(locales, ",")
+	if key == strings.Join(r.locales, ",") {
+		return r
+	}
+
+	r.inLocale.Lock()
+	defer r.inLocale.Unlock()
+	if l, ok := r.inLocale.revealers[key]; ok {
+		return l
+	}
+	l := *r
+	l.locales = locales
+	l.compile()
+	r.inLocale.revealers[key] = &l
+	return &l
+}
+
+// localeSet returns the known codes among codes, lower cased, sorted
+// and without duplicates.
+func localeSet(codes []string) []string {
+	known := Locales()
+	var set []string
+	for _, code := range codes {
+		code = strings.ToLower(code)
+		if contains(known, code) && !contains(set, code) {
+			set = append(set, code)
+		}
+	}
+	sort.Strings(set)
+	return set
+}
+
+// withLocales returns rules followed by those of the Revealer's
+// locales, or rules itself if there are none.
+func (r *Revealer) withLocales(rules *Rules) *Rules {
+	if len(r.locales) == 0 {
+		return rules
+	}
+	merged := *rules
+	for _, code := range r.locales {
+		locale, err := LocaleRules(code)
+		if err != nil {
+			continue
+		}
+		merged = Rules{
+			GeneralFixes: concat(merged.GeneralFixes, locale.GeneralFixes),
+			FindTheAt:    concat(merged.FindTheAt, locale.FindTheAt),
+			LastHurrahs:  concat(merged.LastHurrahs, locale.LastHurrahs),
+			FindTheDots:  concat(merged.FindTheDots, locale.FindTheDots),
+		}
+	}
+	return &merged
+}
+
+// concat returns a new slice of a's pairs followed by b's.
+func concat(a, b [][2]string) [][2]string {
+	return append(append([][2]string(nil), a...), b...)
+}
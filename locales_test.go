@@ -0,0 +1,140 @@
+package revealer
+
+import (
+	"strings"
+	"testing"
+)
+
+type localeTest struct {
+	email          string
+	expectedResult string
+}
+
+var germanTests = []localeTest{
+	{"hans (ät) beispiel punkt de", "hans@beispiel.de"},
+	{"hans klammeraffe beispiel punkt de", "hans@beispiel.de"},
+	{"hans [at-zeichen] beispiel [punkt] de", "hans@beispiel.de"},
+	{"Hans.Meier ÄT Firma PUNKT de", "hans.meier@firma.de"},
+}
+
+var frenchTests = []localeTest{
+	{"jean arobase exemple point fr", "jean@exemple.fr"},
+	{"jean (arobase) exemple (point) fr", "jean@exemple.fr"},
+	{"jean [arrobe] exemple point fr", "jean@exemple.fr"},
+}
+
+var italianTests = []localeTest{
+	{"mario chiocciola esempio punto it", "mario@esempio.it"},
+	{"mario (chiocciola) esempio [punto] it", "mario@esempio.it"},
+}
+
+var russianTests = []localeTest{
+	{"ivan собака пример точка рф", "ivan@пример.рф"},
+	{"IVAN СОБАКА ПРИМЕР ТОЧКА РФ", "ivan@пример.рф"},
+	{"ivan собака example точка ru", "ivan@example.ru"},
+	{"ivan (собака) example (точка) ru", "ivan@example.ru"},
+}
+
+var polishTests = []localeTest{
+	{"jan małpa przyklad kropka pl", "jan@przyklad.pl"},
+	{"jan (malpa) przyklad (kropka) pl", "jan@przyklad.pl"},
+}
+
+var dutchTests = []localeTest{
+	{"jan apenstaartje voorbeeld punt nl", "jan@voorbeeld.nl"},
+	{"jan (apenstaartje) voorbeeld [punt] nl", "jan@voorbeeld.nl"},
+}
+
+var japaneseTests = []localeTest{
+	{"taro アットマーク example ドット jp", "taro@example.jp"},
+	{"taroアットマークexampleドットjp", "taro@example.jp"},
+	{"taro あっと example どっと co ドット jp", "taro@example.co.jp"},
+}
+
+var chineseTests = []localeTest{
+	{"zhang 艾特 example 点 cn", "zhang@example.cn"},
+	{"zhang艾特example(点)cn", "zhang@example.cn"},
+	{"zhang [艾特] 例子 点 中国", "zhang@例子.中国"},
+}
+
+func TestLocales(t *testing.T) {
+	var tables = map[string][]localeTest{
+		"de": germanTests,
+		"fr": frenchTests,
+		"it": italianTests,
+		"ru": russianTests,
+		"pl": polishTests,
+		"nl": dutchTests,
+		"ja": japaneseTests,
+		"zh": chineseTests,
+	}
+
+	if strings.Join(Locales(), ",") != "de,fr,it,ja,nl,pl,ru,zh" {
+		t.Errorf("Expected: de,fr,it,ja,nl,pl,ru,zh, Actual: %v", Locales())
+	}
+
+	for code, tests := range tables {
+		r := New(WithLocales(code))
+		for _, test := range tests {
+			result, err := r.Fix(test.email)
+			if err != nil {
+				t.Errorf("%s: Error: %s", code, err)
+			}
+			if result != test.expectedResult {
+				t.Errorf("%s: Expected: %s, Actual: %s", code, test.expectedResult, result)
+			}
+
+			// or per call
+			result, err = InLocale(code).Fix(test.email)
+			if err != nil {
+				t.Errorf("%s: Error: %s", code, err)
+			}
+			if result != test.expectedResult {
+				t.Errorf("%s: Expected: %s, Actual: %s", code, test.expectedResult, result)
+			}
+
+			// only when switched on
+			if result, err := Fix(test.email); err == nil && result == test.expectedResult {
+				t.Errorf("%s: Expected %q to need the locale, Actual: %s", code, test.email, result)
+			}
+		}
+	}
+}
+
+func TestLocaleRules(t *testing.T) {
+	if _, err := LocaleRules("xx"); err == nil {
+		t.Errorf("Expected an error for an unknown locale")
+	}
+	for _, code := range Locales() {
+		rules, err := LocaleRules(code)
+		if err != nil {
+			t.Errorf("%s: Error: %s", code, err)
+			continue
+		}
+		if len(rules.FindTheAt) == 0 || len(rules.FindTheDots) == 0 {
+			t.Errorf("%s: expected spellings of @ and ., Actual: %+v", code, rules)
+		}
+	}
+
+	// InLocale builds each set of locales once, leaving r as it is
+	r := New()
+	de := r.InLocale("de", "xx")
+	if r.InLocale("DE") != de || r.InLocale("de", "de") != de || de.InLocale() != de {
+		t.Errorf("Expected the same Revealer for the same locales")
+	}
+	if r.InLocale("xx") != r {
+		t.Errorf("Expected r itself for no known locales")
+	}
+	if result, _ := de.InLocale("fr").Fix("jean arobase exemple punkt fr"); result != "jean@exemple.fr" {
+		t.Errorf("Expected: jean@exemple.fr, Actual: %s", result)
+	}
+	if result, err := r.Fix("hans klammeraffe beispiel punkt de"); err == nil && result == "hans@beispiel.de" {
+		t.Errorf("Expected r not to use the locale, Actual: %s", result)
+	}
+
+	// unknown codes are ignored
+	r = New(WithLocales("xx", "de"))
+	if result, _ := r.Fix("hans klammeraffe beispiel punkt de"); result != "hans@beispiel.de" {
+		t.Errorf("Expected: hans@beispiel.de, Actual: %s", result)
+	}
+}
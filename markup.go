@@ -0,0 +1,58 @@
+package revealer
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// mailtoURI matches a mailto: link, up to its query or the end of
+	// the attribute.
+	mailtoURI = regexp.MustCompile(`mailto:([^\s"'<>?]+)`)
+
+	// comment matches an HTML comment, closed or not.
+	comment = regexp.MustCompile(`<!--[\s\S]*?(-->|$)`)
+
+	// tag matches an HTML tag: its name, then any attributes.
+	tag = regexp.MustCompile(`</?([a-z][a-z0-9]*)(\s[^<>]*)?/?>`)
+)
+
+// htmlTags are the elements whose bare tags are stripped. Others, such
+// as "<at>" or "<a>", are spellings of "@" and "." and are left alone.
+var htmlTags = map[string]bool{
+	"abbr": true, "b": true, "bdi": true, "bdo": true, "big": true,
+	"br": true, "cite": true, "code": true, "del": true, "div": true,
+	"em": true, "font": true, "i": true, "ins": true, "kbd": true,
+	"mark": true, "nobr": true, "p": true, "q": true, "s": true,
+	"samp": true, "small": true, "span": true, "strike": true,
+	"strong": true, "sub": true, "sup": true, "tt": true, "u": true,
+	"var": true, "wbr": true,
+}
+
+// unmarkup decodes an address scraped from a web page: the address of
+// a mailto: link, percent-decoded, or else the text with its comments
+// and tags stripped and its entities decoded, e.g.
+// "john&#x40;example&#46;com" or "john<span>@</span>example.com".
+func unmarkup(email string) string {
+	if !strings.ContainsAny(email, "&<") && !strings.Contains(email, "mailto:") {
+		return email
+	}
+
+	if m := mailtoURI.FindStringSubmatch(html.UnescapeString(email)); m != nil {
+		if addr, err := url.PathUnescape(m[1]); err == nil {
+			return addr
+		}
+	}
+
+	email = comment.ReplaceAllString(email, "")
+	email = tag.ReplaceAllStringFunc(email, func(t string) string {
+		m := tag.FindStringSubmatch(t)
+		if htmlTags[m[1]] || m[2] != "" || t[1] == '/' && m[1] == "a" {
+			return ""
+		}
+		return t
+	})
+	return html.UnescapeString(email)
+}
@@ -0,0 +1,65 @@
+package revealer
+
+import (
+	"testing"
+)
+
+func TestUnmarkup(t *testing.T) {
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{"&#106;&#111;&#104;&#110;&#64;&#101;&#120;&#97;&#109;&#112;&#108;&#101;&#46;&#99;&#111;&#109;", "john@example.com"},
+		{"john&#x40;example&#46;com", "john@example.com"},
+		{"john&commat;example&period;com", "john@example.com"},
+		{"john<span>@</span>example<!-- x -->.com", "john@example.com"},
+		{`john<span class="at">@</span>example<b>.</b>com`, "john@example.com"},
+		{`<a href="mailto:john%40example.com">write to john</a>`, "john@example.com"},
+		{"mailto:john.doe%2Bnews@example.com?subject=hi", "john.doe+news@example.com"},
+		{`<a href="&#109;ailto:john@example.com">john</a>`, "john@example.com"},
+		{"john <at> example <dot> com", "john <at> example <dot> com"},
+		{"john<a>example.com", "john<a>example.com"},
+		{"john&lt;at&gt;example.com", "john<at>example.com"},
+		{"john at example dot com", "john at example dot com"},
+	}
+
+	for _, test := range tests {
+		actual := unmarkup(test.input)
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+}
+
+func TestRevealMarkup(t *testing.T) {
+
+	var tests = []struct {
+		email    string
+		expected string
+		unmarked bool
+	}{
+		{"&#106;&#111;&#104;&#110;&#64;&#101;&#120;&#97;&#109;&#112;&#108;&#101;&#46;&#99;&#111;&#109;", "john@example.com", true},
+		{"john&#x40;example&#46;com", "john@example.com", true},
+		{"john<span>@</span>example<!-- x -->.com", "john@example.com", true},
+		{"john<!-- spam -->&#32;at&#32;example<br>&#32;dot&#32;com", "john@example.com", true},
+		{`<a href="mailto:john%40example.com">john</a>`, "john@example.com", true},
+		{"john&lt;at&gt;example&lt;dot&gt;com", "john@example.com", true},
+		{"john <at> example <dot> com", "john@example.com", false},
+	}
+
+	for _, test := range tests {
+		result, err := Reveal(test.email)
+		if err != nil {
+			t.Errorf("%s: Error: %s", test.email, err)
+			continue
+		}
+		if result.Address != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, result.Address)
+		}
+		unmarked := len(result.Steps) > 0 && result.Steps[0] == StepUnmarkup
+		if unmarked != test.unmarked {
+			t.Errorf("%s: expected unmarked: %t, Actual steps: %v", test.email, test.unmarked, result.Steps)
+		}
+	}
+}
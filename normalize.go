@@ -0,0 +1,132 @@
+package revealer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// compatibility maps the characters that spell an address but that
+// NFKC leaves alone.
+var compatibility = map[rune]string{
+	'。': ".", // ideographic full stop
+}
+
+// confusables maps letters of other scripts to the Latin letters they
+// look like, from the Unicode confusables data (UTS #39). They are only
+// mapped in words that mix them with Latin letters, so Russian or Greek
+// words are left alone.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'і': 'i', 'ј': 'j',
+	'о': 'o', 'р': 'p', 'ԛ': 'q', 'ѕ': 's', 'у': 'y', 'ԝ': 'w', 'х': 'x',
+	'ү': 'y',
+	'А': 'a', 'В': 'b', 'С': 'c', 'Е': 'e', 'Н': 'h', 'І': 'i', 'Ј': 'j',
+	'К': 'k', 'М': 'm', 'О': 'o', 'Р': 'p', 'Ѕ': 's', 'Т': 't', 'Х': 'x',
+	'У': 'y',
+
+	// Greek
+	'α': 'a', 'ι': 'i', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'υ': 'u',
+	'Α': 'a', 'Β': 'b', 'Ε': 'e', 'Ζ': 'z', 'Η': 'h', 'Ι': 'i', 'Κ': 'k',
+	'Μ': 'm', 'Ν': 'n', 'Ο': 'o', 'Ρ': 'p', 'Τ': 't', 'Υ': 'y', 'Χ': 'x',
+
+	// Armenian
+	'օ': 'o', 'ս': 'u',
+}
+
+// normalize maps characters that only look like ASCII to it: first
+// the compatibility characters that NFKC folds to ASCII letters,
+// digits, "@" or ".", such as fullwidth "ａｔ", "﹫", mathematical bold
+// "𝐚𝐭" and the ligature "ﬁ", then confusable letters, such as Cyrillic
+// "а", in words that mix them with Latin letters. The rest of email is
+// only composed in NFC. The confusables are a hand-picked subset of
+// UTS #39, the Cyrillic, Greek and Armenian letters that look like
+// Latin ones, not all of confusables.txt: look-alikes from other
+// scripts and symbols are left as they are.
+func normalize(email string) string {
+	email = compat(norm.NFC.String(email))
+
+	var b strings.Builder
+	word := 0
+	for i := 0; i < len(email); {
+		r, size := utf8.DecodeRuneInString(email[i:])
+		if !isWordRune(r) {
+			b.WriteString(unconfuse(email[word:i]))
+			b.WriteString(email[i : i+size])
+			word = i + size
+		}
+		i += size
+	}
+	b.WriteString(unconfuse(email[word:]))
+	return b.String()
+}
+
+// compat returns s with each character that NFKC folds to ASCII
+// letters, digits, "@", "." or a space folded and lower cased.
+func compat(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		c, ok := compatibility[r]
+		if !ok {
+			c = norm.NFKC.String(string(r))
+		}
+		if r >= utf8.RuneSelf && c != string(r) && isCompatASCII(c) {
+			b.WriteString(strings.ToLower(c))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isCompatASCII reports whether s is ASCII letters, digits, "@", "."
+// and spaces only.
+func isCompatASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isAlnum(s[i]) && !strings.ContainsRune("@. ", rune(s[i])) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// unconfuse maps the confusables in word if it also has Latin letters.
+func unconfuse(word string) string {
+	latin, confused := false, false
+	for _, r := range word {
+		if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' {
+			latin = true
+		} else if _, ok := confusable(r); ok {
+			confused = true
+		}
+	}
+	if !latin || !confused {
+		return word
+	}
+	return strings.Map(func(r rune) rune {
+		if c, ok := confusable(r); ok {
+			return c
+		}
+		return r
+	}, word)
+}
+
+// confusable returns the Latin letter r looks like, if r is a letter
+// of another script; Latin letters such as Turkish "ı" are real.
+func confusable(r rune) (rune, bool) {
+	if unicode.Is(unicode.Latin, r) {
+		return r, false
+	}
+	c, ok := confusables[r]
+	return c, ok
+}
+
+// isWordRune reports whether r is part of a word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,72 @@
+package revealer
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{"jane ａｔ example ｄｏｔ com", "jane at example dot com"},
+		{"ＪＡＮＥ＠ＥＸＡＭＰＬＥ．ＣＯＭ", "jane@example.com"},
+		{"jane﹫example﹒com", "jane@example.com"},
+		{"𝐣𝐚𝐧𝐞 𝐚𝐭 𝑒𝑥𝑎𝑚𝑝𝑙𝑒 𝙙𝙤𝙩 𝚌𝚘𝚖 𝟏𝟐𝟑", "jane at example dot com 123"},
+		{"ⓙⓐⓝⓔ ⒶⓉ example.com", "jane at example.com"},
+		{"jane аt gmаil dоt cоm", "jane at gmail dot com"},
+		{"jane at ехаmple.com", "jane at example.com"},
+		{"иван at пример dot рф", "иван at пример dot рф"},
+		{"δοκιμή at παράδειγμα", "δοκιμή at παράδειγμα"},
+		{"用户 at 例子。中国", "用户 at 例子.中国"},
+		{"jane at example.com", "jane at example.com"},
+		{"x at ﬁnance dot com", "x at finance dot com"},
+		{"x² at ex³ dot com ①②⓪ ₄₂", "x2 at ex3 dot com 120 42"},
+		{"x at ㉑ dot com ⒜", "x at 21 dot com ⒜"},
+		{"x at mu\u0308nchen dot de", "x at münchen dot de"},
+		{"ali at yıldız dot com", "ali at yıldız dot com"},
+		{"user at ıstanbul dot com", "user at ıstanbul dot com"},
+	}
+
+	for _, test := range tests {
+		actual := normalize(test.input)
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+}
+
+func TestRevealNormalized(t *testing.T) {
+
+	var tests = []struct {
+		email      string
+		expected   string
+		normalized bool
+	}{
+		{"jane ａｔ example ｄｏｔ com", "jane@example.com", true},
+		{"jane﹫example.com", "jane@example.com", true},
+		{"𝐣𝐚𝐧𝐞 𝐚𝐭 example dot com", "jane@example.com", true},
+		{"jane аt gmаil dоt cоm", "jane@gmail.com", true},
+		{"ivan at пример dot рф", "ivan@пример.рф", false},
+		{"jane at example dot com", "jane@example.com", false},
+		{"x at ﬁnance dot com", "x@finance.com", true},
+		{"ali at yıldız dot com", "ali@yıldız.com", false},
+		{"user at ıstanbul dot com", "user@ıstanbul.com", false},
+	}
+
+	for _, test := range tests {
+		result, err := Reveal(test.email)
+		if err != nil {
+			t.Errorf("%s: Error: %s", test.email, err)
+			continue
+		}
+		if result.Address != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, result.Address)
+		}
+		normalized := len(result.Steps) > 0 && result.Steps[0] == StepNormalize
+		if normalized != test.normalized {
+			t.Errorf("%s: expected normalized: %t, Actual steps: %v", test.email, test.normalized, result.Steps)
+		}
+	}
+}
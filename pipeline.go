@@ -0,0 +1,143 @@
+package revealer
+
+// Stage is a step of the pipeline. Apply takes the address as fixed so
+// far and returns it fixed a little more.
+type Stage interface {
+	Name() string
+	Apply(email string) string
+}
+
+// Pipeline is the order the stages run in. When the address Stages
+// leave behind does not validate, Retry runs over it as a last try.
+type Pipeline struct {
+	Stages []Stage
+	Retry  []Stage
+}
+
+// DefaultPipeline returns the pipeline a Revealer runs without
+// WithPipeline, ready to have stages added:
+//
+//	unmarkup, normalize, unleet, generalFixes, findTheAt,
+//	findTheDots, findTheAt, findTheDots, stripBad, handcraftedFixes,
+//	correctTypos, addDots, checkSpecial
+//
+// retrying findTheAt and findTheDots. correctTypos only runs with
+// WithTypoCorrection.
+func DefaultPipeline() Pipeline {
+	return Pipeline{
+		Stages: builtins(
+			StepUnmarkup,
+			StepNormalize,
+			StepUnleet,
+			StepGeneralFixes,
+			StepFindTheAt,
+			StepFindTheDots,
+			StepFindTheAt,
+			StepFindTheDots,
+			StepStripBad,
+			StepHandcraftedFixes,
+			StepCorrectTypos,
+			StepAddDots,
+			StepCheckSpecial,
+		),
+		Retry: builtins(StepFindTheAt, StepFindTheDots),
+	}
+}
+
+// WithPipeline runs p instead of DefaultPipeline. WithSteps and
+// WithoutSteps still choose which of its built-in stages run.
+func WithPipeline(p Pipeline) Option {
+	return func(r *Revealer) {
+		r.pipeline = p
+	}
+}
+
+// NewStage returns a Stage named name that runs apply, e.g. to strip
+// ticket numbers before the built-in stages see them.
+func NewStage(name string, apply func(email string) string) Stage {
+	return stage{name, apply}
+}
+
+type stage struct {
+	name  string
+	apply func(string) string
+}
+
+func (s stage) Name() string              { return s.name }
+func (s stage) Apply(email string) string { return s.apply(email) }
+
+// Builtin returns the built-in stage with the given Step name, or nil if
+// there is none. In a pipeline it runs with the Revealer's rules and
+// providers; on its own it runs with the defaults.
+func Builtin(name string) Stage {
+	for _, s := range steps {
+		if s == name {
+			return builtin(name)
+		}
+	}
+	return nil
+}
+
+// builtin is a built-in stage, named by its Step constant.
+type builtin string
+
+func builtins(names ...string) []Stage {
+	stages := make([]Stage, len(names))
+	for i, name := range names {
+		stages[i] = builtin(name)
+	}
+	return stages
+}
+
+func (b builtin) Name() string { return string(b) }
+
+func (b builtin) Apply(email string) string {
+	f := &fix{Revealer: defaultRevealer}
+	return f.builtin(string(b))(email)
+}
+
+// builtin returns the fix's function for the built-in stage name.
+func (f *fix) builtin(name string) func(string) string {
+	switch name {
+	case StepUnmarkup:
+		return unmarkup
+	case StepNormalize:
+		return normalize
+	case StepUnleet:
+		return f.unleet
+	case StepGeneralFixes:
+		return f.generalFixes
+	case StepFindTheAt:
+		return f.findTheAt
+	case StepFindTheDots:
+		return f.findTheDots
+	case StepStripBad:
+		return stripBad
+	case StepHandcraftedFixes:
+		return f.handcraftedFixes
+	case StepCorrectTypos:
+		return f.correctTypos
+	case StepAddDots:
+		return f.addDots
+	case StepCheckSpecial:
+		return checkSpecial
+	}
+	return nil
+}
+
+// runStages runs each stage over email in turn. Built-in stages only
+// run when the Revealer's steps include them.
+func (f *fix) runStages(stages []Stage, email string) string {
+	for _, s := range stages {
+		name := s.Name()
+		apply := s.Apply
+		if b, ok := s.(builtin); ok {
+			if !f.steps[name] {
+				continue
+			}
+			apply = f.builtin(string(b))
+		}
+		email = f.run(name, apply, email)
+	}
+	return email
+}
@@ -0,0 +1,89 @@
+package revealer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var ticketIDs = regexp.MustCompile(`\s*\[tkt-[0-9]+\]\s*`)
+
+func stripTickets(email string) string {
+	return ticketIDs.ReplaceAllString(email, " ")
+}
+
+func TestPipeline(t *testing.T) {
+	tickets := DefaultPipeline()
+	tickets.Stages = append([]Stage{NewStage("tickets", stripTickets)}, tickets.Stages...)
+
+	upper := Pipeline{Stages: []Stage{Builtin(StepFindTheAt), NewStage("upper", strings.ToUpper)}}
+
+	var tests = []struct {
+		r        *Revealer
+		email    string
+		expected string
+		steps    []string
+		err      bool
+	}{
+		{New(), "jane at example dot com", "jane@example.com", []string{StepFindTheAt, StepFindTheDots}, false},
+		{New(WithPipeline(tickets)), "jane at [tkt-1234] example dot com", "jane@example.com", []string{"tickets", StepFindTheAt, StepFindTheDots}, false},
+		{New(WithPipeline(tickets), WithoutSteps(StepFindTheDots)), "jane at [tkt-1234] example.com", "jane@example.com", []string{"tickets", StepFindTheAt}, false},
+		{New(WithPipeline(tickets), WithSteps(StepFindTheAt)), "jane at [tkt-1234] example.com", "jane@example.com", []string{"tickets", StepFindTheAt}, false},
+		{New(WithPipeline(upper)), "jane at example.com", "JANE@EXAMPLE.COM", []string{StepFindTheAt, "upper"}, false},
+		{New(WithPipeline(Pipeline{})), "jane at example.com", "", nil, true},
+	}
+
+	for _, test := range tests {
+		result, err := test.r.Reveal(test.email)
+		if (err != nil) != test.err {
+			t.Errorf("%s: expected error: %t, Actual: %v", test.email, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if result.Address != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, result.Address)
+		}
+		if strings.Join(result.Steps, ",") != strings.Join(test.steps, ",") {
+			t.Errorf("%s: expected steps: %v, Actual: %v", test.email, test.steps, result.Steps)
+		}
+	}
+}
+
+func TestBuiltin(t *testing.T) {
+	if Builtin("nope") != nil {
+		t.Errorf("Expected: nil, Actual: %v", Builtin("nope"))
+	}
+
+	var tests = []struct {
+		name     string
+		email    string
+		expected string
+	}{
+		{StepFindTheAt, "jane at example.com", "jane@example.com"},
+		{StepFindTheDots, "example dot com", "example.com"},
+		{StepHandcraftedFixes, "dexgecko (gmail)", "dexgecko @gmail.com"},
+	}
+
+	for _, test := range tests {
+		s := Builtin(test.name)
+		if s.Name() != test.name {
+			t.Errorf("Expected: %s, Actual: %s", test.name, s.Name())
+		}
+		actual := s.Apply(test.email)
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+}
+
+func ExampleWithPipeline() {
+	p := DefaultPipeline()
+	p.Stages = append([]Stage{NewStage("tickets", stripTickets)}, p.Stages...)
+
+	r := New(WithPipeline(p))
+	email, _ := r.Fix("jane at [tkt-1234] example dot com")
+	fmt.Println(email)
+	// Output: jane@example.com
+}
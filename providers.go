@@ -0,0 +1,141 @@
+package revealer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Provider describes a mail provider the fixes know about, so that e.g.
+// "dexgecko (gmail)" becomes "dexgecko@gmail.com".
+type Provider struct {
+	// Domain is where the mail goes, e.g. "gmail.com".
+	Domain string
+
+	// Aliases are other ways people write the provider's name, e.g.
+	// "gee mail". Each is replaced by the first token.
+	Aliases []string
+
+	// Tokens are bare names that imply the domain, e.g. "gmail". When
+	// empty the first label of the domain is used.
+	Tokens []string
+}
+
+// knownProviders fills in the details for DefaultProviders and any
+// other domain passed to WithProviders. googlemail.com is its own
+// provider as addresses there are valid as written.
+var knownProviders = map[string]Provider{
+	"gmail.com":      {Domain: "gmail.com", Aliases: []string{"gee mail", "ge mail", "g mail"}},
+	"googlemail.com": {Domain: "googlemail.com"},
+	"hotmail.com":    {Domain: "hotmail.com"},
+	"qq.com":         {Domain: "qq.com"},
+	"163.com":        {Domain: "163.com"},
+}
+
+// providerFor returns the known provider for domain, or one whose token
+// is the first label of the domain.
+func providerFor(domain string) Provider {
+	domain = strings.ToLower(domain)
+	if p, ok := knownProviders[domain]; ok {
+		return p
+	}
+	return Provider{Domain: domain}
+}
+
+// tokens returns the provider's tokens, defaulting to the first label
+// of its domain.
+func (p Provider) tokens() []string {
+	if len(p.Tokens) == 0 {
+		return []string{strings.SplitN(p.Domain, ".", 2)[0]}
+	}
+	tokens := make([]string, len(p.Tokens))
+	for i, t := range p.Tokens {
+		tokens[i] = strings.ToLower(t)
+	}
+	return tokens
+}
+
+// pairs returns the general fixes for the provider as replacer pairs,
+// e.g. "_gmail_com" to "@gmail.com" and "gee mail" to "gmail".
+func (p Provider) pairs() []string {
+	d := p.Domain
+	at := "@" + d
+	underscored := strings.Replace(d, ".", "_", -1)
+
+	pairs := []string{
+		"." + d, at,
+		".@" + d, at,
+		"@." + d, at,
+		"_" + d, at,
+		"_" + underscored, at,
+		underscored, d,
+		strings.Replace(d, ".", " ", -1), d,
+		"at" + d, at,
+		"at" + strings.Replace(d, ".", "dot", -1), at,
+	}
+
+	tokens := p.tokens()
+	for _, t := range tokens {
+		// the rest of the domain after the token, e.g. ".com"
+		if rest := strings.TrimPrefix(d, t); rest != d && strings.HasPrefix(rest, ".") {
+			pairs = append(pairs,
+				d+rest, d,
+				t+"@"+rest, at,
+			)
+		}
+	}
+	for _, alias := range p.Aliases {
+		pairs = append(pairs, strings.ToLower(alias), tokens[0])
+	}
+	return pairs
+}
+
+// fix repairs the provider's name written in brackets or on its own
+// before or after the name, a missing domain ending and a missing "@".
+func (p Provider) fix(email string) string {
+	at := "@" + p.Domain
+
+	for _, t := range p.tokens() {
+
+		// if the token is in front, e.g. "[gmail]: name"
+		if !strings.Contains(email, "@") {
+			for _, prefix := range []string{"[" + t + "]", "(" + t + ")", t} {
+				if len(email) > len(prefix) && strings.HasPrefix(email, prefix) &&
+					(prefix != t || !startsWord(email[len(t):]) && email[len(t)] != '.') {
+					email = email[len(prefix):] + at
+					break
+				}
+			}
+		}
+
+		// if it ends in the token in brackets, e.g. "name (gmail)"
+		for _, suffix := range []string{"[" + t + "]", "(" + t + ")"} {
+			if len(email) > len(suffix) && strings.HasSuffix(email, suffix) {
+				email = email[:len(email)-len(suffix)] + at
+			}
+		}
+
+		// if it ends in the token without the rest of the domain, e.g.
+		// "name at gmail" but not "name at hotmail" for "mail"
+		for _, suffix := range []string{t, t + "."} {
+			if len(email) > len(suffix) && strings.HasSuffix(email, suffix) &&
+				strings.ContainsRune("@ ([{<", rune(email[len(email)-len(suffix)-1])) {
+				email = email[:len(email)-len(suffix)] + p.Domain
+				break
+			}
+		}
+	}
+
+	// if the domain does not have an @ in front
+	if !strings.Contains(email, "@") && len(email) > len(at) && strings.HasSuffix(email, p.Domain) {
+		email = email[:len(email)-len(p.Domain)] + at
+	}
+
+	return email
+}
+
+// startsWord reports whether s starts with a letter or digit.
+func startsWord(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,74 @@
+package revealer
+
+import (
+	"testing"
+)
+
+func TestProviders(t *testing.T) {
+	custom := New(
+		WithProviders("yahoo.com", "outlook.com", "yandex.ru", "naver.com", "126.com"),
+		WithProvider(Provider{Domain: "Mail.ru", Aliases: []string{"mail ru"}, Tokens: []string{"MailRu"}}),
+	)
+
+	var tests = []struct {
+		r        *Revealer
+		email    string
+		expected string
+	}{
+		{custom, "john (yahoo)", "john@yahoo.com"},
+		{custom, "[yahoo]: bob", "bob@yahoo.com"},
+		{custom, "john at outlook", "john@outlook.com"},
+		{custom, "ivan.yandex.ru", "ivan@yandex.ru"},
+		{custom, "ivan at yandex", "ivan@yandex.ru"},
+		{custom, "kim_naver_com", "kim@naver.com"},
+		{custom, "wang126.com", "wang@126.com"},
+		{custom, "wang@126.", "wang@126.com"},
+		{custom, "petya at mail ru", "petya@mail.ru"},
+		{custom, "petya (mailru)", "petya@mail.ru"},
+		{custom, "bob at gmail", "bob@gmail"},
+		{New(WithProviders("mail.ru")), "x at hotmail", "x@hotmail"},
+		{New(WithProviders("mail.ru")), "x at gmail", "x@gmail"},
+		{New(WithProviders("mail.ru")), "x at mail", "x@mail.ru"},
+		{New(WithProviders("mail.ru")), "x (mail)", "x@mail.ru"},
+		{New(), "zz hotmail", "zz@hotmail"},
+		{New(WithProviders("hotmail.com")), "zz hotmail", "zz@hotmail.com"},
+		{New(), "x at googlemail dot com", "x@googlemail.com"},
+		{New(WithProviders("googlemail.com")), "x at googlemail", "x@googlemail.com"},
+		{New(WithProvider(Provider{Domain: "gmail.com"})), "reddaly at gee mail dot com", "reddaly@gee.mail.com"},
+	}
+
+	for _, test := range tests {
+		actual, err := test.r.Fix(test.email)
+		if err != nil {
+			t.Errorf("%s: Error: %s", test.email, err)
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+}
+
+func TestProviderFix(t *testing.T) {
+	p := providerFor("gmail.com")
+
+	var tests = []struct {
+		email    string
+		expected string
+	}{
+		{"[gmail]: test", ": test@gmail.com"},
+		{"gmail test", " test@gmail.com"},
+		{"gmailtest", "gmailtest"},
+		{"test (gmail)", "test @gmail.com"},
+		{"test@gmail.", "test@gmail.com"},
+		{"test gmail.com", "test @gmail.com"},
+		{"test@foo.gmail.com", "test@foo.gmail.com"},
+	}
+
+	for _, test := range tests {
+		actual := p.fix(test.email)
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package revealer
+
+import (
+	_ "embed" // for the public suffix list
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// publicSuffixList is the Public Suffix List as published; `make
+// suffixes` updates it. Only its ICANN section is used, as the private
+// section lists hosting services whose users have their own mail.
+//
+//go:embed publicsuffix.dat
+var publicSuffixList string
+
+// endICANN marks the end of the ICANN section of the list.
+const endICANN = "===END ICANN DOMAINS==="
+
+// reservedTLDs are the top-level names RFC 2606 reserves, which are
+// cut off as junk, e.g. ".invalid" in "test7@example.com.invalid".
+var reservedTLDs = map[string]bool{
+	"example":   true,
+	"invalid":   true,
+	"localhost": true,
+	"test":      true,
+}
+
+// suffix rule kinds, as in the Public Suffix List format
+const (
+	ruleNormal = iota + 1
+	ruleWildcard
+	ruleException
+)
+
+var (
+	suffixOnce  sync.Once
+	suffixRules map[string]int
+)
+
+// parseSuffixes turns a list in Public Suffix List format, up to the
+// end of its ICANN section, into rules keyed by the suffix without its
+// "*." or "!" prefix.
+func parseSuffixes(list string) map[string]int {
+	rules := make(map[string]int)
+	for _, line := range strings.Split(list, "\n") {
+		if strings.Contains(line, endICANN) {
+			break
+		}
+
+		// rules end at the first whitespace
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "//") {
+			continue
+		}
+		rule := strings.ToLower(fields[0])
+		switch {
+		case strings.HasPrefix(rule, "!"):
+			rules[rule[1:]] = ruleException
+		case strings.HasPrefix(rule, "*."):
+			rules[rule[2:]] = ruleWildcard
+		default:
+			if rules[rule] == 0 {
+				rules[rule] = ruleNormal
+			}
+		}
+	}
+	return rules
+}
+
+// suffixLen returns how many of the trailing labels form a known public
+// suffix, or 0 if the last label is not a known top-level domain.
+func suffixLen(labels []string) int {
+	suffixOnce.Do(func() {
+		suffixRules = parseSuffixes(publicSuffixList)
+	})
+
+	// the list has internationalized suffixes in Unicode
+	labels = append([]string(nil), labels...)
+	for i, label := range labels {
+		if decoded, err := toUnicode(label); err == nil {
+			labels[i] = decoded
+		}
+	}
+
+	n := 0
+	for i := len(labels) - 1; i >= 0; i-- {
+		name := strings.Join(labels[i:], ".")
+		switch suffixRules[name] {
+		case ruleException:
+			// "!www.ck" makes "www.ck" registrable despite "*.ck"
+			return len(labels) - i - 1
+		case ruleNormal:
+			n = len(labels) - i
+		case ruleWildcard:
+			n = len(labels) - i
+			if i > 0 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// knownDomain reports whether domain is a registrable name under a known
+// public suffix, e.g. "example.co.uk" but not "co.uk" or "localhost".
+func knownDomain(domain string) bool {
+	labels := strings.Split(domain, ".")
+	for _, label := range labels {
+		if !isLabel(label) {
+			return false
+		}
+	}
+	n := suffixLen(labels)
+	return n > 0 && n < len(labels)
+}
+
+// trimAfterDomain cuts off anything after the domain, e.g. ".invalid"
+// in "test7@example.com.invalid" or " or bob@..." in "alice@gmail.com
+// or bob@example.org". The domain is the longest prefix ending in a
+// known public suffix, so "x@foo.com.au" and "x@my.community.org" stay
+// as they are. Spaces may only appear in the first label of the domain
+// as addDots may still turn them into dots. A domain whose last label
+// is not a known suffix, such as "x@my.studio.unknown", is left alone.
+func trimAfterDomain(email string) string {
+	start := strings.Index(email, "@") + 1
+	domain := email[start:]
+
+	for end := len(domain); end > 0; end-- {
+		if end < len(domain) && !strings.ContainsRune(". @", rune(domain[end])) {
+			continue
+		}
+		if end < len(domain) && domain[end] == '.' && !junkLabels(domain[end+1:]) {
+			continue
+		}
+		if cutsDomain(domain[:end]) {
+			return email[:start+end]
+		}
+	}
+	return email
+}
+
+// junkLabels reports whether the labels after a dot, up to any space
+// or "@", may be cut off: none, or ending in a known or reserved
+// top-level name.
+func junkLabels(rest string) bool {
+	if i := strings.IndexAny(rest, " @"); i >= 0 {
+		rest = rest[:i]
+	}
+	if rest == "" {
+		return true
+	}
+	last := strings.ToLower(rest[strings.LastIndex(rest, ".")+1:])
+	return reservedTLDs[last] || suffixLen([]string{last}) > 0
+}
+
+// cutsDomain reports whether domain ends in a known public suffix with a
+// label in front of it.
+func cutsDomain(domain string) bool {
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		label = strings.TrimSpace(label)
+		if i == 0 {
+			label = strings.Replace(label, " ", "", -1)
+		}
+		if !isLabel(label) {
+			return false
+		}
+		labels[i] = label
+	}
+	n := suffixLen(labels)
+	return n > 0 && n < len(labels)
+}
+
+// isLabel reports whether label is a non-empty run of letters, marks,
+// digits and hyphens.
+func isLabel(label string) bool {
+	if label == "" {
+		return false
+	}
+	for _, r := range label {
+		if r != '-' && !unicode.IsLetter(r) && !unicode.IsMark(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package revealer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuffixLen(t *testing.T) {
+
+	var tests = []struct {
+		domain string
+		n      int
+	}{
+		{"example.com", 1},
+		{"example.co.uk", 2},
+		{"foo.com.au", 2},
+		{"my.community.org", 1},
+		{"saao.ac.za", 2},
+		{"example.invalid", 0},
+		{"localhost", 0},
+		{"anything.ck", 2},
+		{"www.ck", 1},
+		{"foo.www.ck", 1},
+		{"acme.design", 1},
+		{"foo.london", 1},
+		{"example.tokyo", 1},
+		{"user.github.io", 1},
+	}
+
+	for _, test := range tests {
+		n := suffixLen(strings.Split(test.domain, "."))
+		if n != test.n {
+			t.Errorf("%s: Expected: %d, Actual: %d", test.domain, test.n, n)
+		}
+	}
+}
+
+func TestTrimAfterDomain(t *testing.T) {
+
+	var tests = []struct {
+		email    string
+		expected string
+	}{
+		{"x@foo.com.au", "x@foo.com.au"},
+		{"x@my.community.org", "x@my.community.org"},
+		{"x@example.co.uk junk", "x@example.co.uk"},
+		{"x@example.com.br.", "x@example.com.br"},
+		{"test7@example.com.invalid", "test7@example.com"},
+		{"x@gmail.com or bob@example.org", "x@gmail.com"},
+		{"x@gmail . com junk", "x@gmail . com"},
+		{"x@forsters freehold.com", "x@forsters freehold.com"},
+		{"x@localhost", "x@localhost"},
+		{"x@co.uk", "x@co.uk"},
+		{"bernhard zq1.de", "bernhard zq1.de"},
+		{"x@my.studio.design", "x@my.studio.design"},
+		{"x@acme.agency junk", "x@acme.agency"},
+		{"x@my.studio.unknown", "x@my.studio.unknown"},
+		{"x@example.com.test", "x@example.com"},
+	}
+
+	for _, test := range tests {
+		actual := trimAfterDomain(test.email)
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+}
+
+func TestKnownDomain(t *testing.T) {
+
+	var tests = []struct {
+		domain string
+		known  bool
+	}{
+		{"acme.design", true},
+		{"acme.agency", true},
+		{"foo.london", true},
+		{"x.berlin", true},
+		{"x.nyc", true},
+		{"example.co.uk", true},
+		{"co.uk", false},
+		{"example.invalid", false},
+		{"localhost", false},
+	}
+
+	for _, test := range tests {
+		if known := knownDomain(test.domain); known != test.known {
+			t.Errorf("%s: Expected: %t, Actual: %t", test.domain, test.known, known)
+		}
+	}
+
+	r := New(WithValidation(ValidateStrict))
+	for _, email := range []string{"jane@acme.design", "x@acme.agency", "bob@foo.london", "x@my.studio.design"} {
+		if actual, err := r.Fix(email); err != nil || actual != email {
+			t.Errorf("Expected: %s, Actual: %s (%v)", email, actual, err)
+		}
+	}
+}
+
+func TestParseSuffixes(t *testing.T) {
+	rules := parseSuffixes("// comment\n\ncom\n*.ck\n!www.ck\nco.uk extra\n// ===END ICANN DOMAINS===\ngithub.io\n")
+
+	expected := map[string]int{"com": ruleNormal, "ck": ruleWildcard, "www.ck": ruleException, "co.uk": ruleNormal}
+	if len(rules) != len(expected) {
+		t.Errorf("Expected: %v, Actual: %v", expected, rules)
+	}
+	for rule, kind := range expected {
+		if rules[rule] != kind {
+			t.Errorf("%s: Expected: %d, Actual: %d", rule, kind, rules[rule])
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package revealer
+
+import "strings"
+
+// Result describes a revealed email address and how it was found.
+type Result struct {
+	// Input is the obfuscated address as given.
+	Input string
+
+	// Address is the revealed address, the same as Fix returns.
+	Address string
+
+	// Local and Domain are Address split on its last "@".
+	Local  string
+	Domain string
+
+	// DomainASCII and DomainUnicode are Domain in punycode ("xn--")
+	// and in Unicode. They are only set for an internationalized
+	// domain, e.g. "xn--mnchen-3ya.de" and "münchen.de".
+	DomainASCII   string
+	DomainUnicode string
+
+	// Decoder names the decoder that found Address (see the Decoder
+	// constants), or is empty if none was needed.
+	Decoder string
+
+	// Corrected is the domain as written when correctTypos corrected
+	// it (see WithTypoCorrection), e.g. "gmial.com" for "gmail.com".
+	Corrected string
+
+	// Steps lists, in order, the pipeline steps that changed the
+	// address (see the Step constants). A step that ran more than
+	// once, such as findTheAt, may be listed more than once.
+	Steps []string
+
+	// Confidence runs from 1 for a near-certain address, such as
+	// "x at y dot com", down towards 0 as more guesses were needed.
+	Confidence float64
+
+	// Guesses lists the guesses that lowered Confidence (see the
+	// Guess constants).
+	Guesses []string
+}
+
+// result builds the Result for a valid address.
+func (f *fix) result(input, address string) *Result {
+	at := strings.LastIndex(address, "@")
+	r := &Result{
+		Input:      input,
+		Address:    address,
+		Local:      address[:at],
+		Domain:     address[at+1:],
+		Decoder:    f.decoder,
+		Corrected:  f.corrected,
+		Steps:      f.applied,
+		Confidence: f.confidence(),
+		Guesses:    f.guessed,
+	}
+
+	// validate has checked the domain, so neither conversion fails
+	ascii, _ := toASCII(r.Domain)
+	uni, _ := toUnicode(r.Domain)
+	if ascii != uni {
+		r.DomainASCII, r.DomainUnicode = ascii, uni
+	}
+	return r
+}
@@ -0,0 +1,48 @@
+package revealer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReveal(t *testing.T) {
+
+	var tests = []struct {
+		email  string
+		local  string
+		domain string
+		steps  []string
+	}{
+		{"test@example.com", "test", "example.com", nil},
+		{"y.imai at ocaml.jp", "y.imai", "ocaml.jp", []string{StepFindTheAt}},
+		{"zxytim[at]gmail[dot]com", "zxytim", "gmail.com", []string{StepFindTheAt, StepFindTheDots}},
+		{"dexgecko (gmail)", "dexgecko", "gmail.com", []string{StepHandcraftedFixes, StepAddDots}},
+		{"debackerl gmail com", "debackerl", "gmail.com", []string{StepGeneralFixes, StepHandcraftedFixes, StepAddDots}},
+	}
+
+	for _, test := range tests {
+		result, err := Reveal(test.email)
+		if err != nil {
+			t.Errorf("Error: %s", err)
+			continue
+		}
+		if result.Input != test.email {
+			t.Errorf("Expected Input: %s, Actual: %s", test.email, result.Input)
+		}
+		if result.Address != test.local+"@"+test.domain {
+			t.Errorf("Expected: %s@%s, Actual: %s", test.local, test.domain, result.Address)
+		}
+		if result.Local != test.local || result.Domain != test.domain {
+			t.Errorf("Expected: %s %s, Actual: %s %s", test.local, test.domain, result.Local, result.Domain)
+		}
+		if strings.Join(result.Steps, ",") != strings.Join(test.steps, ",") {
+			t.Errorf("%s: expected steps: %v, Actual: %v", test.email, test.steps, result.Steps)
+		}
+	}
+
+	// errors come back with no result
+	result, err := Reveal("broken")
+	if err == nil || result != nil {
+		t.Errorf("Should have errored!")
+	}
+}
@@ -5,18 +5,209 @@ package revealer
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"net/mail"
 	"regexp"
 	"strings"
 )
 
-var (
-	debugEmail = ""
-	logSteps   bool
-	reg        *regexp.Regexp
+var reg *regexp.Regexp
+
+// Names of the pipeline steps, for use with WithSteps and WithoutSteps.
+const (
+	StepUnmarkup         = "unmarkup"
+	StepNormalize        = "normalize"
+	StepUnleet           = "unleet"
+	StepGeneralFixes     = "generalFixes"
+	StepFindTheAt        = "findTheAt"
+	StepFindTheDots      = "findTheDots"
+	StepStripBad         = "stripBad"
+	StepHandcraftedFixes = "handcraftedFixes"
+	StepCorrectTypos     = "correctTypos"
+	StepAddDots          = "addDots"
+	StepCheckSpecial     = "checkSpecial"
 )
 
+// Validation sets how strictly a revealed address is checked before
+// it is returned.
+type Validation int
+
+const (
+	// ValidateParse accepts anything net/mail.ParseAddress accepts.
+	ValidateParse Validation = iota
+
+	// ValidateStrict also requires a bare address (no display name)
+	// whose domain is registrable under a known public suffix.
+	ValidateStrict
+)
+
+// DefaultProviders are the domains of the mail providers the fixes know
+// about, e.g. "dexgecko (gmail)" becomes "dexgecko@gmail.com".
+var DefaultProviders = []string{"gmail.com", "qq.com", "163.com"}
+
+var steps = []string{
+	StepUnmarkup,
+	StepNormalize,
+	StepUnleet,
+	StepGeneralFixes,
+	StepFindTheAt,
+	StepFindTheDots,
+	StepStripBad,
+	StepHandcraftedFixes,
+	StepCorrectTypos,
+	StepAddDots,
+	StepCheckSpecial,
+}
+
+// stepLabels are used when logging each step.
+var stepLabels = map[string]string{
+	StepUnmarkup:         "Unmarkup:",
+	StepNormalize:        "Normalize:",
+	StepUnleet:           "Unleet:",
+	StepGeneralFixes:     "General fixes:",
+	StepFindTheAt:        "Find at:",
+	StepFindTheDots:      "Find dots:",
+	StepStripBad:         "Strip bad:",
+	StepHandcraftedFixes: "Hand fixes:",
+	StepCorrectTypos:     "Typos:",
+	StepAddDots:          "Add dots:",
+	StepCheckSpecial:     "Special chars:",
+}
+
+// builtinRules are the rules a Revealer uses without WithRules.
+var builtinRules = DefaultRules()
+
+// defaultRevealer backs the package level Fix.
+var defaultRevealer = New()
+
+// Revealer "de-obfuscates" email addresses. Create one with New; a
+// Revealer is not modified after it is created, so it is safe for
+// concurrent use.
+type Revealer struct {
+	steps       map[string]bool
+	pipeline    Pipeline
+	providers   []Provider
+	rules       *Rules
+	locales     []string
+	inLocale    *localeCache
+	decoders    []string
+	typoDomains []string
+	general     *strings.Replacer
+	at          *strings.Replacer
+	hurrahs     *strings.Replacer
+	dots        *strings.Replacer
+	leetWords   map[string]bool
+	validation  Validation
+	smtputf8    bool
+	tracer      Tracer
+	traceIf     func(email string) bool
+}
+
+// Option configures a Revealer.
+type Option func(*Revealer)
+
+// New returns a Revealer configured by opts. With no options it
+// behaves exactly like the package level Fix.
+func New(opts ...Option) *Revealer {
+	r := &Revealer{
+		steps:    make(map[string]bool),
+		pipeline: DefaultPipeline(),
+	}
+	for _, s := range steps {
+		if s != StepCorrectTypos { // see WithTypoCorrection
+			r.steps[s] = true
+		}
+	}
+	for _, d := range DefaultProviders {
+		r.providers = append(r.providers, providerFor(d))
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.locales = localeSet(r.locales)
+	r.inLocale = &localeCache{revealers: make(map[string]*Revealer)}
+	r.compile()
+	return r
+}
+
+// compile builds the Revealer's replacers from its rules, locales and
+// providers.
+func (r *Revealer) compile() {
+	rules := r.rules
+	if rules == nil {
+		rules = builtinRules
+	}
+	rules = r.withLocales(rules)
+	var pairs []string
+	for _, p := range r.providers {
+		pairs = append(pairs, p.pairs()...)
+	}
+	general, at, hurrahs, dots := rules.GeneralFixes, rules.FindTheAt, rules.LastHurrahs, rules.FindTheDots
+	if r.smtputf8 {
+		general, at, hurrahs, dots = forSMTPUTF8(general), forSMTPUTF8(at), forSMTPUTF8(hurrahs), forSMTPUTF8(dots)
+	}
+	r.general = replacer(general, pairs...)
+	r.at = replacer(at)
+	r.hurrahs = replacer(hurrahs)
+	r.dots = replacer(dots)
+	r.leetWords = r.markers()
+}
+
+// WithSteps runs only the named built-in steps (see the Step
+// constants). Stages added with WithPipeline always run.
+func WithSteps(names ...string) Option {
+	return func(r *Revealer) {
+		r.steps = make(map[string]bool)
+		for _, name := range names {
+			r.steps[name] = true
+		}
+	}
+}
+
+// WithoutSteps skips the named built-in steps.
+func WithoutSteps(names ...string) Option {
+	return func(r *Revealer) {
+		for _, name := range names {
+			delete(r.steps, name)
+		}
+	}
+}
+
+// WithProviders replaces DefaultProviders with the given domains. A
+// domain the package does not know about gets the first label of the
+// domain as its token, e.g. "yandex" for "yandex.ru".
+func WithProviders(domains ...string) Option {
+	return func(r *Revealer) {
+		r.providers = nil
+		for _, d := range domains {
+			r.providers = append(r.providers, providerFor(d))
+		}
+	}
+}
+
+// WithProvider adds p to the providers, replacing any with the same
+// domain.
+func WithProvider(p Provider) Option {
+	return func(r *Revealer) {
+		p.Domain = strings.ToLower(p.Domain)
+		for i, q := range r.providers {
+			if q.Domain == p.Domain {
+				r.providers[i] = p
+				return
+			}
+		}
+		r.providers = append(r.providers, p)
+	}
+}
+
+// WithValidation sets how strictly revealed addresses are checked.
+func WithValidation(v Validation) Option {
+	return func(r *Revealer) {
+		r.validation = v
+	}
+}
+
 func init() {
 	// Domain names may be formed from the set of alphanumeric
 	// ASCII characters (a-z, A-Z, 0-9), but characters are
@@ -28,181 +219,191 @@ func init() {
 	// make a simple regex to clear out anything that doesn't belong
 	// to be used later.
 	var err error
-	reg, err = regexp.Compile("[^a-z0-9-. ]+")
+	reg, err = regexp.Compile(`[^\p{L}\p{M}\p{Nd}\-. ]+`)
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
-// Fix "de-obfucates" email addresses
+// Fix "de-obfucates" email addresses using the default Revealer.
 func Fix(email string) (string, error) {
+	return defaultRevealer.Fix(email)
+}
 
-	// check for empty string first
-	if email == "" {
-		return "", errors.New("email address cannot be empty")
-	}
+// Reveal "de-obfucates" an email address using the default Revealer.
+func Reveal(email string) (*Result, error) {
+	return defaultRevealer.Reveal(email)
+}
 
-	// debugging?
-	if email == debugEmail {
-		logSteps = true
-		logStep("Original:", email)
-	} else {
-		logSteps = false
+// Fix "de-obfucates" email addresses
+func (r *Revealer) Fix(email string) (string, error) {
+	result, err := r.Reveal(email)
+	if err != nil {
+		return "", err
 	}
+	return result.Address, nil
+}
 
-	// general fixes
-	fixed := strings.ToLower(email)
-	fixed = generalFixes(fixed)
-
-	// find the @
-	fixed = findTheAt(fixed)
-
-	// find the dots
-	fixed = findTheDots(fixed)
-
-	// find the @ (round 2)
-	fixed = findTheAt(fixed)
+// Reveal "de-obfucates" an email address like Fix, and also reports
+// how it got there.
+func (r *Revealer) Reveal(email string) (*Result, error) {
+	return r.reveal(email, variant{}, r.tracerFor(email))
+}
 
-	// find the dots (round 2)
-	fixed = findTheDots(fixed)
+// reveal runs the pipeline making the choices in v, tracing to t if
+// it is not nil, then any decoders.
+func (r *Revealer) reveal(email string, v variant, t Tracer) (*Result, error) {
+	result, err := r.revealPipeline(email, v, t)
+	return r.decode(email, v, t, result, err)
+}
 
-	// strip bad characters in domain
-	fixed = stripBad(fixed)
+// revealPipeline runs the pipeline making the choices in v.
+func (r *Revealer) revealPipeline(email string, v variant, t Tracer) (*Result, error) {
 
-	// perform any "special" hardcoded fixes
-	fixed = handcraftedFixes(fixed)
+	// check for empty string first
+	if email == "" {
+		return nil, ErrEmpty
+	}
 
-	// add dots, or @ as needed
-	stripped := addDots(fixed)
+	f := &fix{
+		Revealer: r,
+		variant:  v,
+		email:    email,
+		tracer:   t,
+		guessed:  v.guesses(),
+	}
 
-	// remove any special characters
-	stripped = checkSpecial(stripped)
+	fixed := f.runStages(r.pipeline.Stages, r.lower(email))
 
 	// check if valid
-	_, err := mail.ParseAddress(stripped)
-	if err == nil {
-		logStep("Valid:", stripped)
-		return stripped, nil
+	fixed = r.finish(fixed)
+	if r.validate(fixed) == nil {
+		return f.result(email, fixed), nil
 	}
 
 	//
 	// Try again!
 	//
 
-	// find the @
-	stripped = findTheAt(stripped)
-
-	// find the dots
-	stripped = findTheDots(stripped)
+	fixed = f.runStages(r.pipeline.Retry, fixed)
 
 	// check if valid
-	_, err = mail.ParseAddress(stripped)
+	fixed = r.finish(fixed)
+	err := r.validate(fixed)
 	if err == nil {
-		logStep("Valid:", stripped)
-		return stripped, nil
+		return f.result(email, fixed), nil
 	}
 
-	return "", errors.New("unable to fix email address: " + email + " -> " + stripped)
+	return nil, &FixError{Input: email, Last: fixed, Stage: f.lastApplied(), Err: err}
 }
 
-func handcraftedFixes(email string) string {
+// fix holds the state of a single call to Fix.
+type fix struct {
+	*Revealer
+	variant
+	email   string
+	tracer  Tracer
+	applied []string
+	guessed []string
+
+	// corrected is the domain as written before correctTypos.
+	corrected string
+}
 
-	// If [gmail] is in front
-	if len(email) > 7 {
-		if email[0:7] == "[gmail]" {
-			email = email[7:] + "@gmail.com"
-		}
+// run applies step to email, recording and tracing what it did.
+func (f *fix) run(name string, step func(string) string, email string) string {
+	fixed := step(email)
+	if fixed != email {
+		f.applied = append(f.applied, name)
+		f.guessStep(name, email, fixed)
 	}
-
-	// If (gmail) is in front
-	if len(email) > 7 {
-		if email[0:7] == "(gmail)" {
-			email = email[7:] + "@gmail.com"
-		}
+	if f.tracer != nil {
+		f.tracer.Trace(Event{
+			Email:   f.email,
+			Step:    name,
+			Input:   email,
+			Output:  fixed,
+			Changed: fixed != email,
+		})
 	}
+	return fixed
+}
 
-	// If gmail is in front
-	if len(email) > 5 {
-		if email[0:5] == "gmail" {
-			email = email[5:] + "@gmail.com"
-		}
-	}
+func (f *fix) addDots(email string) string {
+	return addDotsVariant(email, f.variant)
+}
 
-	// if it ends in gmail (without .com) add .com
-	if len(email) > 6 {
-		if email[len(email)-5:] == "gmail" || email[len(email)-6:] == "gmail." {
-			email = email + ".com"
-		}
+// lastApplied is the last step that changed the address, if any.
+func (f *fix) lastApplied() string {
+	if len(f.applied) == 0 {
+		return ""
 	}
+	return f.applied[len(f.applied)-1]
+}
 
-	// if it ends in (gmail)
-	if len(email) > 7 {
-		if email[len(email)-7:] == "(gmail)" {
-			email = email[:len(email)-7] + "@gmail.com"
-		}
+// lower lower cases email before the pipeline runs. With SMTPUTF8
+// only ASCII letters are, as the local part may be case sensitive.
+func (r *Revealer) lower(email string) string {
+	if r.smtputf8 {
+		return lowerASCII(email)
 	}
+	return strings.ToLower(email)
+}
 
-	// if it ends in [gmail]
-	if len(email) > 7 {
-		if email[len(email)-7:] == "[gmail]" {
-			email = email[:len(email)-7] + "@gmail.com"
-		}
+// finish lower cases the domain left unlowered by lower.
+func (r *Revealer) finish(email string) string {
+	if r.smtputf8 {
+		return lowerDomain(email)
 	}
+	return email
+}
 
-	// if it ends in qq (without .com) add .com
-	if len(email) > 3 {
-		if email[len(email)-2:] == "qq" || email[len(email)-3:] == "qq." {
-			email = email + ".com"
+// validate returns why email fails the Revealer's validation, or nil.
+func (r *Revealer) validate(email string) error {
+	address := email
+	if r.smtputf8 {
+		if err := validateSMTPUTF8(email); err != nil {
+			return err
 		}
-	}
-
-	// if it ends in 163 (without .com) add .com
-	if len(email) > 4 {
-		if email[len(email)-3:] == "163" || email[len(email)-4:] == "163." {
-			email = email + ".com"
+	} else {
+		addr, err := mail.ParseAddress(email)
+		if err != nil {
+			return err
 		}
-	}
-
-	// if gmail.com does not have an @ in front
-	if len(email) > 10 {
-		if email[len(email)-9:] == "gmail.com" && email[len(email)-10:len(email)-9] != "@" {
-			email = email[0:len(email)-9] + "@gmail.com"
+		if r.validation == ValidateStrict && (addr.Name != "" || addr.Address != email) {
+			return ErrNotStrict
 		}
-	}
+		address = addr.Address
 
-	// if qq.com does not have an @ in front
-	if len(email) > 7 {
-		if email[len(email)-6:] == "qq.com" && email[len(email)-7:len(email)-6] != "@" {
-			email = email[0:len(email)-6] + "@qq.com"
+		// net/mail takes any UTF-8, and invalid UTF-8, in the local part
+		if local := address[:strings.LastIndex(address, "@")]; !isASCII(local) {
+			return fmt.Errorf("local part %q is not ASCII, which needs WithSMTPUTF8", local)
 		}
 	}
 
-	// if 163.com does not have an @ in front
-	if len(email) > 8 {
-		if email[len(email)-7:] == "163.com" && email[len(email)-8:len(email)-7] != "@" {
-			email = email[0:len(email)-7] + "@163.com"
-		}
+	domain := address[strings.LastIndex(address, "@")+1:]
+	if err := checkIDN(domain); err != nil {
+		return err
 	}
+	if r.validation == ValidateStrict && !knownDomain(domain) {
+		return ErrNotStrict
+	}
+	return nil
+}
 
-	// FIXME an attempt to strip off everything after ".com" but this
-	// may be inaccurate - can you have .com.uk for example?
-	split := strings.SplitAfter(email, ".com")
-	email = split[0]
-
-	split = strings.SplitAfter(email, ".org")
-	email = split[0]
-
-	split = strings.SplitAfter(email, ".net")
-	email = split[0]
-
-	split = strings.SplitAfter(email, ".edu")
-	email = split[0]
-
-	logStep("Hand fixes:", email)
-	return email
+func (r *Revealer) handcraftedFixes(email string) string {
+	for _, p := range r.providers {
+		email = p.fix(email)
+	}
+	return trimAfterDomain(email)
 }
 
 func addDots(email string) string {
+	return addDotsVariant(email, variant{})
+}
+
+// addDotsVariant is addDots making the choices in v.
+func addDotsVariant(email string, v variant) string {
 
 	// split string on "@"
 	portions := strings.Split(email, "@")
@@ -217,6 +418,15 @@ func addDots(email string) string {
 	// now split first portion (name) on spaces
 	spaces := strings.Split(trimmed, " ")
 
+	// if we have no @ and were told which space to use, use it
+	if len(portions) == 1 && v.atSpace > 0 && v.atSpace < len(spaces) {
+		portions = []string{
+			strings.Join(spaces[:v.atSpace], " "),
+			strings.Join(spaces[v.atSpace:], " "),
+		}
+		spaces = spaces[:v.atSpace]
+	}
+
 	// if we have no @ and just one space, try an @
 	if len(portions) == 1 && len(spaces) == 2 {
 		addAt := ""
@@ -235,19 +445,28 @@ func addDots(email string) string {
 	}
 
 	// if we have a lot of sections just strip spaces
-	if len(spaces) > 3 {
+	if v.spaces == spacesDropped || v.spaces == spacesDefault && len(spaces) > 3 {
+		if v.spaces == spacesDropped && !v.keepTag {
+			portions[0] = strings.Split(portions[0], " + ")[0]
+		}
 		portions[0] = strings.Replace(portions[0], " ", "", -1)
 	} else {
 		// otherwise reassemble - placing dots between spaces
 		newString := ""
+		tag := false
 		for i, s := range spaces {
 			s = strings.Trim(s, " ")
-			if s == "+" { // dump "+" and everything after (+spam, +junk, etc.)
+			if s == "+" && !v.keepTag { // dump "+" and everything after (+spam, +junk, etc.)
 				break
+			} else if s == "+" {
+				tag = true
 			} else {
 				if s != "" && s != "." {
 					if i == 0 {
 						newString = s
+					} else if tag {
+						newString = newString + "+" + s
+						tag = false
 					} else {
 
 						newString = newString + "." + s
@@ -303,7 +522,6 @@ func addDots(email string) string {
 		}
 	}
 
-	logStep("Add dots:", email)
 	return email
 }
 
@@ -321,44 +539,44 @@ func stripBad(email string) string {
 		}
 	}
 
-	logStep("Strip bad:", email)
 	return email
 }
 
+// specialReplacer blanks out the special characters, which are only
+// allowed between double quotes. They are: Space and "(),:;<>@[\]
+var specialReplacer = strings.NewReplacer(
+	"(", " ",
+	")", " ",
+	",", " ",
+	":", " ",
+	";", " ",
+	"<", " ",
+	">", " ",
+	// "@", " ",
+	"[", " ",
+	"]", " ",
+
+	// The restrictions for special characters are that they must
+	// only be used when contained between quotation marks, and
+	// that 3 of them (The space, backslash \ and quotation mark "
+	// must also be preceded by a backslash \ (e.g. "\ \\\"").
+	"\\ ", " ",
+	"\\", " ",
+	"\"", " ",
+)
+
 func checkSpecial(email string) string {
 
 	// Special characters are allowed with restrictions -
 	// They must be between double quotes.
 
-	// They are: Space and "(),:;<>@[\]
-	r := strings.NewReplacer(
-		"(", " ",
-		")", " ",
-		",", " ",
-		":", " ",
-		";", " ",
-		"<", " ",
-		">", " ",
-		// "@", " ",
-		"[", " ",
-		"]", " ",
-
-		// The restrictions for special characters are that they must
-		// only be used when contained between quotation marks, and
-		// that 3 of them (The space, backslash \ and quotation mark "
-		// must also be preceded by a backslash \ (e.g. "\ \\\"").
-		"\\ ", " ",
-		"\\", " ",
-		"\"", " ",
-	)
-
 	// split email on quotes
 	chunks := strings.Split(email, "\"")
 
 	// range through chunks
 	for i, s := range chunks {
 		if i == 0 {
-			email = r.Replace(s)
+			email = specialReplacer.Replace(s)
 		} else {
 			// i is odd (because we start at zero it means we are even!)
 			// and we are not at the first chunk
@@ -370,9 +588,9 @@ func checkSpecial(email string) string {
 				// and we are not at the first chunk
 				// and there is an odd number of chunks
 				if i%2 == 0 && i > 0 && len(chunks)%2 != 0 {
-					email = email + "\"" + r.Replace(s)
+					email = email + "\"" + specialReplacer.Replace(s)
 				} else {
-					email = email + r.Replace(s)
+					email = email + specialReplacer.Replace(s)
 				}
 			}
 		}
@@ -381,573 +599,23 @@ func checkSpecial(email string) string {
 	trimmed := strings.Trim(email, " ")
 	trimmed = strings.Trim(trimmed, ".")
 
-	logStep("Special chars:", trimmed)
 	return trimmed
 }
 
-func findTheAt(email string) string {
-
-	r := strings.NewReplacer(
-
-		// remove duplicates
-		"@@@@", "@",
-		"@@@", "@",
-		"@@", "@",
-		"@ @", "@",
-
-		// some strings may appear inside a name so we will
-		// only look for those that have spaces around them
-		// NOTE multiples in inverse order
-		" atatat ", "@",
-		" atat ", "@",
-		" at ", "@",
-		" ta ", "@",
-		" at-sign ", "@",
-		" located-at ", "@",
-		" atsign ", "@",
-		" isat ", "@",
-		" atmark ", "@",
-		" splat ", "@",
-		" atmk ", "@",
-		" shift2 ", "@",
-		" 4t ", "@",
-
-		// misc @
-		"＠", "@",
-		"ät", "@",
-		"æt", "@",
-		"ət", "@",
-		"åt", "@",
-		"a-t", "@",
-		"а", "a", // a is a different rune, really...
-		".a.t.", "@",
-		"u+0040", "@",
-		"arroba", "@", // at in spanish
-
-		"[@t]", "@",
-		"(@t)", "@",
-		"{@t}", "@",
-		"<@t>", "@",
-
-		// -------------
-		// @ substitutes
-		// -------------
-		".@.", "@",
-		"!@!", "@",
-		"#@#", "@",
-		"$@$", "@",
-		"%@%", "@",
-		"&@&", "@",
-		"'@'", "@",
-		"*@*", "@",
-		"+@+", "@",
-		"-@-", "@",
-		"/@/", "@",
-		"=@=", "@",
-		"?@?", "@",
-		"^@^", "@",
-		"_@_", "@",
-		"`@`", "@",
-		"|@|", "@",
-		"{@{", "@",
-		"}@}", "@",
-		"{@}", "@",
-		"~@~", "@",
-		"\"@\"", "@",
-		"(@(", "@",
-		")@)", "@",
-		"(@)", "@",
-		",@,", "@",
-		":@:", "@",
-		";@;", "@",
-		"<@<", "@",
-		">@>", "@",
-		"<@>", "@",
-		"@@@", "@",
-		"\\@\\", "@",
-		"[@[", "@",
-		"]@]", "@",
-		"[@]", "@",
-		"*@*", "@",
-		"#@#", "@",
-
-		// one side
-		// NOTE: other side handled via special domain handling
-		".@", "@",
-		"!@", "@",
-		"#@", "@",
-		"$@", "@",
-		"%@", "@",
-		"&@", "@",
-		"'@", "@",
-		"*@", "@",
-		"+@", "@",
-		"-@", "@",
-		"/@", "@",
-		"=@", "@",
-		"?@", "@",
-		"^@", "@",
-		"_@", "@",
-		"`@", "@",
-		"|@", "@",
-		"{@", "@",
-		"}@", "@",
-		"~@", "@",
-		"\"@", "@",
-		"(@", "@",
-		")@", "@",
-		",@", "@",
-		":@", "@",
-		";@", "@",
-		"<@", "@",
-		">@", "@",
-		"<@", "@",
-		"@@", "@",
-		"\\@", "@",
-		"[@", "@",
-		"]@", "@",
-		"*@", "@",
-
-		// ----------------
-		// "at" substitutes
-		// ----------------
-		".at.", "@",
-		"!at!", "@",
-		"#at#", "@",
-		"$at$", "@",
-		"%at%", "@",
-		"&at&", "@",
-		"'at'", "@",
-		"*at*", "@",
-		"+at+", "@",
-		"-at-", "@",
-		"/at/", "@",
-		"=at=", "@",
-		"?at?", "@",
-		"^at^", "@",
-		"_at_", "@",
-		"`at`", "@",
-		"|at|", "@",
-		"{at{", "@",
-		"}at}", "@",
-		"{at}", "@",
-		"~at~", "@",
-		"\"at\"", "@",
-		"(at(", "@",
-		")at)", "@",
-		"(at)", "@",
-		",at,", "@",
-		":at:", "@",
-		";at;", "@",
-		"<at<", "@",
-		">at>", "@",
-		"<at>", "@",
-		"@at@", "@",
-		"\\at\\", "@",
-		"[at[", "@",
-		"]at]", "@",
-		"[at]", "@",
-		"*at*", "@",
-
-		// one side
-		".at", "@",
-		"$at", "@",
-		"(at", "@",
-		",at", "@",
-		"*at", "@",
-
-		// ----------------
-		// "a" substitutes
-		// ----------------
-		"!a!", "@",
-		"#a#", "@",
-		"$a$", "@",
-		"%a%", "@",
-		"&a&", "@",
-		"'a'", "@",
-		"*a*", "@",
-		"+a+", "@",
-		"-a-", "@",
-		"/a/", "@",
-		"=a=", "@",
-		"?a?", "@",
-		"^a^", "@",
-		"_a_", "@",
-		"`a`", "@",
-		"|a|", "@",
-		"{a{", "@",
-		"}a}", "@",
-		"{a}", "@",
-		"~a~", "@",
-		"\"a\"", "@",
-		"(a(", "@",
-		")a)", "@",
-		"(a)", "@",
-		",a,", "@",
-		":a:", "@",
-		";a;", "@",
-		"<a<", "@",
-		">a>", "@",
-		"<a>", "@",
-		"@a@", "@",
-		"\\a\\", "@",
-		"[a[", "@",
-		"]a]", "@",
-		"[a]", "@",
-		"*a*", "@",
-
-		// last hurrahs
-		"#", "@",
-		"*", "@",
-	)
-
-	fixed := r.Replace(email)
-
-	logStep("Find at:", fixed)
-	return fixed
-}
-
-func findTheDots(email string) string {
-
-	r := strings.NewReplacer(
-
-		// remove duplicates
-		"....", ".",
-		"...", ".",
-		"..", ".",
-		". .", ".",
-
-		// some strings may appear inside a name so we will
-		// only look for those that have spaces around them
-		// NOTE multiples in inverse order
-
-		" dotdotdot ", ".",
-		" dotdot ", ".",
-		" dot ", ".",
-		" otd ", ".",
-		" d0t ", ".",
-		" dat ", ".",
-		" dotsym ", ".",
-		" point ", ".",
-		" period ", ".",
-		" dt ", ".",
-		" daught ", ".",
-		" spot ", ".",
-
-		// misc .
-		",", ".",
-		"d-o-t", ".",
-
-		// -------------
-		// "." substitutes
-		// -------------
-		"!.!", ".",
-		"#.#", ".",
-		"$.$", ".",
-		"%.%", ".",
-		"&.&", ".",
-		"'.'", ".",
-		"*.*", ".",
-		"+.+", ".",
-		"-.-", ".",
-		"/./", ".",
-		"=.=", ".",
-		"?.?", ".",
-		"^.^", ".",
-		"_._", ".",
-		"`.`", ".",
-		"|.|", ".",
-		"{.{", ".",
-		"}.}", ".",
-		"{.}", ".",
-		"~.~", ".",
-		"\".\"", ".",
-		"(.(", ".",
-		").)", ".",
-		"(.)", ".",
-		",.,", ".",
-		":.:", ".",
-		";.;", ".",
-		"<.<", ".",
-		">.>", ".",
-		"<.>", ".",
-		// "@.@", ".",
-		"\\.\\", ".",
-		"[.[", ".",
-		"].]", ".",
-		"[.]", ".",
-		"*.*", ".",
-
-		// ----------------
-		// "dot" substitutes
-		// ----------------
-		".dot.", ".",
-		"!dot!", ".",
-		"#dot#", ".",
-		"$dot$", ".",
-		"%dot%", ".",
-		"&dot&", ".",
-		"'dot'", ".",
-		"*dot*", ".",
-		"+dot+", ".",
-		"-dot-", ".",
-		"/dot/", ".",
-		"=dot=", ".",
-		"?dot?", ".",
-		"^dot^", ".",
-		"_dot_", ".",
-		"`dot`", ".",
-		"|dot|", ".",
-		"{dot{", ".",
-		"}dot}", ".",
-		"{dot}", ".",
-		"~dot~", ".",
-		"\"dot\"", ".",
-		"(dot(", ".",
-		")dot)", ".",
-		"(dot)", ".",
-		",dot,", ".",
-		":dot:", ".",
-		";dot;", ".",
-		"<dot<", ".",
-		">dot>", ".",
-		"<dot>", ".",
-		"@dot@", ".",
-		"\\dot\\", ".",
-		"[dot[", ".",
-		"]dot]", ".",
-		"[dot]", ".",
-		"*dot*", ".",
-
-		// ----------------
-		// "d0t" substitutes
-		// ----------------
-		".d0t.", ".",
-		"!d0t!", ".",
-		"#d0t#", ".",
-		"$d0t$", ".",
-		"%d0t%", ".",
-		"&d0t&", ".",
-		"'d0t'", ".",
-		"*d0t*", ".",
-		"+d0t+", ".",
-		"-d0t-", ".",
-		"/d0t/", ".",
-		"=d0t=", ".",
-		"?d0t?", ".",
-		"^d0t^", ".",
-		"_d0t_", ".",
-		"`d0t`", ".",
-		"|d0t|", ".",
-		"{d0t{", ".",
-		"}d0t}", ".",
-		"{d0t}", ".",
-		"~d0t~", ".",
-		"\"d0t\"", ".",
-		"(d0t(", ".",
-		")d0t)", ".",
-		"(d0t)", ".",
-		",d0t,", ".",
-		":d0t:", ".",
-		";d0t;", ".",
-		"<d0t<", ".",
-		">d0t>", ".",
-		"<d0t>", ".",
-		"@d0t@", ".",
-		"\\d0t\\", ".",
-		"[d0t[", ".",
-		"]d0t]", ".",
-		"[d0t]", ".",
-		"*d0t*", ".",
-
-		// ----------------
-		// "dt" substitutes
-		// ----------------
-		".dt.", ".",
-		"!dt!", ".",
-		"#dt#", ".",
-		"$dt$", ".",
-		"%dt%", ".",
-		"&dt&", ".",
-		"'dt'", ".",
-		"*dt*", ".",
-		"+dt+", ".",
-		"-dt-", ".",
-		"/dt/", ".",
-		"=dt=", ".",
-		"?dt?", ".",
-		"^dt^", ".",
-		"_dt_", ".",
-		"`dt`", ".",
-		"|dt|", ".",
-		"{dt{", ".",
-		"}dt}", ".",
-		"{dt}", ".",
-		"~dt~", ".",
-		"\"dt\"", ".",
-		"(dt(", ".",
-		")dt)", ".",
-		"(dt)", ".",
-		",dt,", ".",
-		":dt:", ".",
-		";dt;", ".",
-		"<dt<", ".",
-		">dt>", ".",
-		"<dt>", ".",
-		"@dt@", ".",
-		"\\dt\\", ".",
-		"[dt[", ".",
-		"]dt]", ".",
-		"[dt]", ".",
-		"*dt*", ".",
-
-		// ----------------
-		// "d" substitutes
-		// ----------------
-		".d.", ".",
-		"!d!", ".",
-		"#d#", ".",
-		"$d$", ".",
-		"%d%", ".",
-		"&d&", ".",
-		"'d'", ".",
-		"*d*", ".",
-		"+d+", ".",
-		"-d-", ".",
-		"/d/", ".",
-		"=d=", ".",
-		"?d?", ".",
-		"^d^", ".",
-		"_d_", ".",
-		"`d`", ".",
-		"|d|", ".",
-		"{d{", ".",
-		"}d}", ".",
-		"{d}", ".",
-		"~d~", ".",
-		"\"d\"", ".",
-		"(d(", ".",
-		")d)", ".",
-		"(d)", ".",
-		",d,", ".",
-		":d:", ".",
-		";d;", ".",
-		"<d<", ".",
-		">d>", ".",
-		"<d>", ".",
-		"@d@", ".",
-		"\\d\\", ".",
-		"[d[", ".",
-		"]d]", ".",
-		"[d]", ".",
-		"*d*", ".",
-	)
-
-	fixed := r.Replace(email)
-
-	logStep("Find dots:", fixed)
-	return fixed
+// findTheAt runs the spellings of "@" and then the last hurrahs.
+func (r *Revealer) findTheAt(email string) string {
+	return r.hurrahs.Replace(r.at.Replace(email))
 }
 
-func generalFixes(email string) string {
-
-	r := strings.NewReplacer(
-
-		"{{{{", "{",
-		"{{{", "{",
-		"{{", "{",
-		"}}}}", "}",
-		"}}}", "}",
-		"}}", "}",
-
-		"((((", "(",
-		"(((", "(",
-		"((", "(",
-		"))))", ")",
-		")))", ")",
-		"))", ")",
-
-		"[[[[", "[",
-		"[[[", "[",
-		"[[", "[",
-		"]]]]", "]",
-		"]]]", "]",
-		"]]", "]",
-
-		"++++", "+",
-		"+++", "+",
-		"++", "+",
-
-		// bad endings
-		"@com", ".com",
-		"@org", ".org",
-		"@net", ".net",
-		"@edu", ".edu",
-
-		// misc
-		".gmail.com", "@gmail.com",
-		"g.mail.com", "gmail.com",
-		".@gmail.com", "@gmail.com",
-		"gmail.com.com", "gmail.com",
-		"gmail com", "gmail.com",
-		"_gmail_com", "@gmail.com",
-		"gmail_com", "gmail.com",
-		"atgmail.com", "@gmail.com",
-		"atgmaildotcom", "@gmail.com",
-		"google email", "google.com",
-		"gee mail", "gmail",
-		"ge mail", "gmail",
-		"g mail", "gmail",
-
-		// hotmail
-		"_hotmail.com", "@hotmail.com",
-		".hotmail.com", "@hotmail.com",
-		"_hotmail_com", "@hotmail.com",
-		"athotmail.com", "@hotmail.com",
-
-		"dotcalm", ".com",
-		"dat.com", ".com",
-		"dot calm", ".com",
-		"dat com", ".com",
-		"dat calm", ".com",
-		" calm", ".com",
-		".calm", ".com",
-		"dotcom", ".com",
-		"@.com", ".com",
-
-		"atnospam", "@",
-		"nospam", "",
-		"n0spam", "",
-		"n0_spam", "",
-		"no_spam", "",
-		"n0-spam", "",
-		"no-spam", "",
-		"n*o*s*p*a*m", "",
-		"n*0*s*p*a*m", "",
-
-		"qq@.com", "@qq.com",
-		"@.qq.com", "@qq.com",
-		".qq.com", "@qq.com",
-
-		"163@.com", "@163.com",
-		"@.163.com", "@163.com",
-		".163.com", "@163.com",
-	)
-
-	fixed := r.Replace(email)
-
-	logStep("General fixes:", fixed)
-	return fixed
+// findTheDots runs the spellings of ".".
+func (r *Revealer) findTheDots(email string) string {
+	return r.dots.Replace(email)
 }
 
-func logStep(step, output string) {
-	if logSteps {
-		paddedStep, err := padding(step, 14, " ")
-		if err != nil {
-			log.Println(err)
-		}
-		log.Println(paddedStep + " " + output)
-	}
+// generalFixes runs the general fixes, including those of the Revealer's
+// providers.
+func (r *Revealer) generalFixes(email string) string {
+	return r.general.Replace(email)
 }
 
 // padding will pad a string out to a defined length using specified character
@@ -0,0 +1,113 @@
+package revealer
+
+import (
+	"bytes"
+	_ "embed" // for the default rules
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//go:embed rules.json
+var defaultRules []byte
+
+// Rules are the substitution tables the fixes run, each an ordered list
+// of old and new string pairs as taken by strings.NewReplacer. Where two
+// old strings match at the same place the earlier pair wins, so longer
+// spellings go first.
+type Rules struct {
+	// GeneralFixes run first, e.g. "dotcom" to ".com". The providers
+	// add their own after these.
+	GeneralFixes [][2]string `json:"generalFixes"`
+
+	// FindTheAt are the spellings of "@", e.g. " at " or "[at]".
+	FindTheAt [][2]string `json:"findTheAt"`
+
+	// LastHurrahs are guesses at "@" run after FindTheAt, e.g. "#".
+	LastHurrahs [][2]string `json:"lastHurrahs"`
+
+	// FindTheDots are the spellings of ".", e.g. " dot " or "(dot)".
+	FindTheDots [][2]string `json:"findTheDots"`
+}
+
+// DefaultRules returns a copy of the rules the package ships with,
+// ready to be changed and passed to WithRules.
+func DefaultRules() *Rules {
+	rules, err := LoadRules(bytes.NewReader(defaultRules))
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// LoadRules reads rules in the JSON format of DefaultRules. A missing
+// group is empty, so e.g. {"findTheAt": [[" at ", "@"]]} finds " at "
+// and nothing else.
+func LoadRules(r io.Reader) (*Rules, error) {
+	var rules Rules
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rules); err != nil {
+		return nil, err
+	}
+	for name, group := range rules.groups() {
+		for i, pair := range group {
+			if pair[0] == "" {
+				return nil, fmt.Errorf("rule %d of %s has nothing to replace", i, name)
+			}
+		}
+	}
+	return &rules, nil
+}
+
+// Disable removes the pairs that replace any of olds from every group,
+// e.g. Disable("#") stops "#" being taken for "@".
+func (rules *Rules) Disable(olds ...string) *Rules {
+	for _, group := range []*[][2]string{&rules.GeneralFixes, &rules.FindTheAt, &rules.LastHurrahs, &rules.FindTheDots} {
+		kept := (*group)[:0]
+		for _, pair := range *group {
+			if !contains(olds, pair[0]) {
+				kept = append(kept, pair)
+			}
+		}
+		*group = kept
+	}
+	return rules
+}
+
+// groups returns the rule groups by their JSON names.
+func (rules *Rules) groups() map[string][][2]string {
+	return map[string][][2]string{
+		"generalFixes": rules.GeneralFixes,
+		"findTheAt":    rules.FindTheAt,
+		"lastHurrahs":  rules.LastHurrahs,
+		"findTheDots":  rules.FindTheDots,
+	}
+}
+
+// WithRules replaces the default rules. The rules are compiled when the
+// Revealer is created, so changing them afterwards has no effect.
+func WithRules(rules *Rules) Option {
+	return func(r *Revealer) {
+		r.rules = rules
+	}
+}
+
+// replacer compiles pairs, followed by any extra old and new strings.
+func replacer(pairs [][2]string, extra ...string) *strings.Replacer {
+	oldnew := make([]string, 0, 2*len(pairs)+len(extra))
+	for _, pair := range pairs {
+		oldnew = append(oldnew, pair[0], pair[1])
+	}
+	return strings.NewReplacer(append(oldnew, extra...)...)
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
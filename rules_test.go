@@ -0,0 +1,77 @@
+package revealer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultRules(t *testing.T) {
+	rules := DefaultRules()
+
+	for name, group := range rules.groups() {
+		if len(group) == 0 {
+			t.Errorf("%s: expected rules, Actual: none", name)
+		}
+	}
+
+	// a copy each time
+	rules.Disable("#")
+	if len(DefaultRules().LastHurrahs) == len(rules.LastHurrahs) {
+		t.Errorf("Expected: a fresh copy, Actual: %v", DefaultRules().LastHurrahs)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+
+	var tests = []struct {
+		json string
+		err  bool
+	}{
+		{`{"findTheAt": [[" at ", "@"]], "findTheDots": [[" dot ", "."]]}`, false},
+		{`{}`, false},
+		{`{"findTheAt": [["", "@"]]}`, true},
+		{`{"findTheAts": [[" at ", "@"]]}`, true},
+		{`[`, true},
+	}
+
+	for _, test := range tests {
+		_, err := LoadRules(strings.NewReader(test.json))
+		if (err != nil) != test.err {
+			t.Errorf("%s: expected error: %t, Actual: %v", test.json, test.err, err)
+		}
+	}
+}
+
+func TestWithRules(t *testing.T) {
+	added := DefaultRules()
+	added.FindTheAt = append(added.FindTheAt, [2]string{" at-symbol ", "@"})
+	added.FindTheDots = append(added.FindTheDots, [2]string{"[punto]", "."})
+
+	minimal, err := LoadRules(strings.NewReader(`{"findTheAt": [[" at ", "@"]], "findTheDots": [[" dot ", "."]]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		r        *Revealer
+		email    string
+		expected string
+		err      bool
+	}{
+		{New(WithRules(added)), "lucia at-symbol ejemplo[punto]es", "lucia@ejemplo.es", false},
+		{New(), "felix021 # gmail.com", "felix021@gmail.com", false},
+		{New(WithRules(DefaultRules().Disable("#"))), "felix021 # gmail.com", "felix021.#@gmail.com", false},
+		{New(WithRules(minimal)), "test at example dot com", "test@example.com", false},
+		{New(WithRules(minimal)), "test atsign example dot com", "", true},
+	}
+
+	for _, test := range tests {
+		actual, err := test.r.Fix(test.email)
+		if (err != nil) != test.err {
+			t.Errorf("%s: expected error: %t, Actual: %v", test.email, test.err, err)
+		}
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// Package server serves revealer over HTTP, with JSON requests and
+// responses, for callers that are not written in Go.
+//
+// The endpoints are:
+//
+//	POST /fix         {"email": "..."}            one address
+//	POST /fix/batch   {"emails": ["...", ...]}    many addresses, in order
+//	POST /candidates  {"email": "...", "n": 3}    ranked readings of one address
+//	POST /extract     {"text": "..."}             addresses found in free text
+//	GET  /health                                  {"status": "ok"}
+//
+// An address that cannot be fixed is not an HTTP error: its response
+// carries an Error saying why. Every fixed address comes with the
+// trace of the pipeline steps that ran.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/dstroot/revealer"
+)
+
+// Limits on a request.
+const (
+	MaxBodyBytes = 1 << 20
+	MaxBatch     = 1000
+)
+
+// Kinds of Error.
+const (
+	KindEmpty      = "empty"
+	KindUnfixable  = "unfixable"
+	KindNotStrict  = "not_strict"
+	KindBadIDN     = "bad_idn"
+	KindBadRequest = "bad_request"
+)
+
+// FixRequest is the body of POST /fix and POST /candidates.
+type FixRequest struct {
+	Email string `json:"email"`
+	N     int    `json:"n,omitempty"`
+}
+
+// BatchRequest is the body of POST /fix/batch.
+type BatchRequest struct {
+	Emails []string `json:"emails"`
+}
+
+// ExtractRequest is the body of POST /extract.
+type ExtractRequest struct {
+	Text string `json:"text"`
+}
+
+// FixResponse is one revealed address, or why it could not be.
+type FixResponse struct {
+	Input         string   `json:"input"`
+	Address       string   `json:"address,omitempty"`
+	Local         string   `json:"local,omitempty"`
+	Domain        string   `json:"domain,omitempty"`
+	DomainASCII   string   `json:"domain_ascii,omitempty"`
+	DomainUnicode string   `json:"domain_unicode,omitempty"`
+	Decoder       string   `json:"decoder,omitempty"`
+	Corrected     string   `json:"corrected,omitempty"`
+	Confidence    float64  `json:"confidence,omitempty"`
+	Steps         []string `json:"steps,omitempty"`
+	Guesses       []string `json:"guesses,omitempty"`
+	Error         *Error   `json:"error,omitempty"`
+	Trace         []Step   `json:"trace,omitempty"`
+}
+
+// Error classifies a failure (see the Kind constants).
+type Error struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	Stage   string `json:"stage,omitempty"`
+	Last    string `json:"last,omitempty"`
+}
+
+// Step is one pipeline step of a trace.
+type Step struct {
+	Step    string `json:"step"`
+	Input   string `json:"input"`
+	Output  string `json:"output"`
+	Changed bool   `json:"changed"`
+}
+
+// BatchResponse is the body returned by POST /fix/batch.
+type BatchResponse struct {
+	Results []FixResponse `json:"results"`
+}
+
+// CandidatesResponse is the body returned by POST /candidates.
+type CandidatesResponse struct {
+	Candidates []FixResponse `json:"candidates"`
+	Error      *Error        `json:"error,omitempty"`
+}
+
+// Match is an address found by POST /extract.
+type Match struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+	FixResponse
+}
+
+// ExtractResponse is the body returned by POST /extract.
+type ExtractResponse struct {
+	Matches []Match `json:"matches"`
+}
+
+// Server is an http.Handler serving a Revealer.
+type Server struct {
+	r   *revealer.Revealer
+	mux *http.ServeMux
+}
+
+// New returns a Server for r, or for the default Revealer if r is nil.
+func New(r *revealer.Revealer) *Server {
+	if r == nil {
+		r = revealer.New()
+	}
+	s := &Server{r: r, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/fix", s.post(s.fix))
+	s.mux.HandleFunc("/fix/batch", s.post(s.batch))
+	s.mux.HandleFunc("/candidates", s.post(s.candidates))
+	s.mux.HandleFunc("/extract", s.post(s.extract))
+	s.mux.HandleFunc("/health", s.health)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mux.ServeHTTP(w, req)
+}
+
+// post only lets POST requests through to h.
+func (s *Server) post(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, "use POST")
+			return
+		}
+		req.Body = http.MaxBytesReader(w, req.Body, MaxBodyBytes)
+		h(w, req)
+	}
+}
+
+func (s *Server) fix(w http.ResponseWriter, req *http.Request) {
+	var body FixRequest
+	if !decode(w, req, &body) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.reveal(body.Email))
+}
+
+func (s *Server) batch(w http.ResponseWriter, req *http.Request) {
+	var body BatchRequest
+	if !decode(w, req, &body) {
+		return
+	}
+	if len(body.Emails) > MaxBatch {
+		writeError(w, http.StatusRequestEntityTooLarge, "too many emails in one batch")
+		return
+	}
+
+	resp := BatchResponse{Results: make([]FixResponse, len(body.Emails))}
+	for i, email := range body.Emails {
+		resp.Results[i] = s.reveal(email)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) candidates(w http.ResponseWriter, req *http.Request) {
+	var body FixRequest
+	if !decode(w, req, &body) {
+		return
+	}
+
+	resp := CandidatesResponse{Candidates: []FixResponse{}}
+	results, err := s.r.Candidates(body.Email, body.N)
+	if err != nil {
+		resp.Error = classify(err)
+	}
+	for _, result := range results {
+		resp.Candidates = append(resp.Candidates, response(body.Email, result, nil))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) extract(w http.ResponseWriter, req *http.Request) {
+	var body ExtractRequest
+	if !decode(w, req, &body) {
+		return
+	}
+
+	resp := ExtractResponse{Matches: []Match{}}
+	for _, m := range s.r.Extract(body.Text) {
+		resp.Matches = append(resp.Matches, Match{
+			Start:       m.Start,
+			End:         m.End,
+			Text:        m.Text,
+			FixResponse: response(m.Text, m.Result, nil),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) health(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// reveal reveals email, tracing each step.
+func (s *Server) reveal(email string) FixResponse {
+	var trace []Step
+	result, err := s.r.RevealTrace(email, revealer.TracerFunc(func(e revealer.Event) {
+		trace = append(trace, Step{e.Step, e.Input, e.Output, e.Changed})
+	}))
+	resp := response(email, result, err)
+	resp.Trace = trace
+	return resp
+}
+
+// response converts what Reveal returned.
+func response(input string, result *revealer.Result, err error) FixResponse {
+	if err != nil {
+		return FixResponse{Input: input, Error: classify(err)}
+	}
+	return FixResponse{
+		Input:         input,
+		Address:       result.Address,
+		Local:         result.Local,
+		Domain:        result.Domain,
+		DomainASCII:   result.DomainASCII,
+		DomainUnicode: result.DomainUnicode,
+		Decoder:       result.Decoder,
+		Corrected:     result.Corrected,
+		Confidence:    result.Confidence,
+		Steps:         result.Steps,
+		Guesses:       result.Guesses,
+	}
+}
+
+// classify turns an error from revealer into an Error.
+func classify(err error) *Error {
+	e := &Error{Kind: KindUnfixable, Message: err.Error()}
+	var fixErr *revealer.FixError
+	if errors.As(err, &fixErr) {
+		e.Stage, e.Last = fixErr.Stage, fixErr.Last
+	}
+	switch {
+	case errors.Is(err, revealer.ErrEmpty):
+		e.Kind = KindEmpty
+	case errors.Is(err, revealer.ErrNotStrict):
+		e.Kind = KindNotStrict
+	case errors.Is(err, revealer.ErrIDN):
+		e.Kind = KindBadIDN
+	}
+	return e
+}
+
+// decode reads the JSON body of req into v, answering 400 if it can't.
+func decode(w http.ResponseWriter, req *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(req.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "bad JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]*Error{"error": {Kind: KindBadRequest, Message: msg}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
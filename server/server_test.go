@@ -0,0 +1,174 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dstroot/revealer"
+)
+
+// do sends body to path and decodes the JSON response into v.
+func do(t *testing.T, h http.Handler, method, path, body string, v interface{}) int {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("%s %s: expected JSON, Actual Content-Type: %s", method, path, ct)
+	}
+	if err := json.NewDecoder(rec.Body).Decode(v); err != nil {
+		t.Errorf("%s %s: bad JSON response: %s", method, path, err)
+	}
+	return rec.Code
+}
+
+func TestFix(t *testing.T) {
+
+	h := New(nil)
+
+	var tests = []struct {
+		body    string
+		address string
+		kind    string
+		trace   int
+	}{
+		{`{"email": "y.imai at ocaml.jp"}`, "y.imai@ocaml.jp", "", 12},
+		{`{"email": "broken"}`, "", KindUnfixable, 14},
+		{`{"email": ""}`, "", KindEmpty, 0},
+	}
+
+	for _, test := range tests {
+		var resp FixResponse
+		if code := do(t, h, "POST", "/fix", test.body, &resp); code != http.StatusOK {
+			t.Errorf("%s: expected status 200, Actual: %d", test.body, code)
+		}
+		if resp.Address != test.address {
+			t.Errorf("Expected: %s, Actual: %s", test.address, resp.Address)
+		}
+		if test.kind == "" && resp.Error != nil || test.kind != "" && (resp.Error == nil || resp.Error.Kind != test.kind) {
+			t.Errorf("%s: expected error kind %q, Actual: %+v", test.body, test.kind, resp.Error)
+		}
+		if len(resp.Trace) != test.trace {
+			t.Errorf("%s: expected %d trace steps, Actual: %d", test.body, test.trace, len(resp.Trace))
+		}
+	}
+
+	// the trace shows each step
+	var resp FixResponse
+	do(t, h, "POST", "/fix", `{"email": "y.imai at ocaml.jp"}`, &resp)
+	step := Step{revealer.StepFindTheAt, "y.imai at ocaml.jp", "y.imai@ocaml.jp", true}
+	if resp.Trace[4] != step {
+		t.Errorf("Expected: %+v, Actual: %+v", step, resp.Trace[4])
+	}
+
+	// the decoder is reported
+	var decoded FixResponse
+	do(t, New(revealer.New(revealer.WithDecoders())), "POST", "/fix", `{"email": "moc.liamg@nhoj"}`, &decoded)
+	if decoded.Address != "john@gmail.com" || decoded.Decoder != revealer.DecoderReverse {
+		t.Errorf("Expected: john@gmail.com by %s, Actual: %+v", revealer.DecoderReverse, decoded)
+	}
+
+	// a typo correction is reported
+	var corrected FixResponse
+	do(t, New(revealer.New(revealer.WithTypoCorrection())), "POST", "/fix", `{"email": "john@gmial.com"}`, &corrected)
+	if corrected.Address != "john@gmail.com" || corrected.Corrected != "gmial.com" {
+		t.Errorf("Expected: john@gmail.com from gmial.com, Actual: %+v", corrected)
+	}
+
+	// strict validation is classified
+	var strict FixResponse
+	do(t, New(revealer.New(revealer.WithValidation(revealer.ValidateStrict))), "POST", "/fix", `{"email": "test at localhost"}`, &strict)
+	if strict.Error == nil || strict.Error.Kind != KindNotStrict || strict.Error.Last != "test@localhost" {
+		t.Errorf("Expected a %s error, Actual: %+v", KindNotStrict, strict.Error)
+	}
+
+	// so are bad internationalized domains
+	var idn FixResponse
+	do(t, h, "POST", "/fix", `{"email": "user@xn--zz.de"}`, &idn)
+	if idn.Error == nil || idn.Error.Kind != KindBadIDN {
+		t.Errorf("Expected a %s error, Actual: %+v", KindBadIDN, idn.Error)
+	}
+
+	// and good ones come in both forms
+	var both FixResponse
+	do(t, h, "POST", "/fix", `{"email": "user at münchen dot de"}`, &both)
+	if both.DomainASCII != "xn--mnchen-3ya.de" || both.DomainUnicode != "münchen.de" {
+		t.Errorf("Expected: xn--mnchen-3ya.de and münchen.de, Actual: %s and %s", both.DomainASCII, both.DomainUnicode)
+	}
+}
+
+func TestBatch(t *testing.T) {
+
+	var resp BatchResponse
+	code := do(t, New(nil), "POST", "/fix/batch", `{"emails": ["zxytim[at]gmail[dot]com", "broken", "y.imai at ocaml.jp"]}`, &resp)
+	if code != http.StatusOK {
+		t.Errorf("Expected status 200, Actual: %d", code)
+	}
+	expected := []string{"zxytim@gmail.com", "", "y.imai@ocaml.jp"}
+	if len(resp.Results) != len(expected) {
+		t.Fatalf("Expected %d results, Actual: %d", len(expected), len(resp.Results))
+	}
+	for i, result := range resp.Results {
+		if result.Address != expected[i] {
+			t.Errorf("Expected: %s, Actual: %s", expected[i], result.Address)
+		}
+	}
+	if resp.Results[1].Error == nil || resp.Results[1].Error.Kind != KindUnfixable {
+		t.Errorf("Expected an error, Actual: %+v", resp.Results[1].Error)
+	}
+
+	emails, _ := json.Marshal(BatchRequest{Emails: make([]string, MaxBatch+1)})
+	var tooMany map[string]*Error
+	if code := do(t, New(nil), "POST", "/fix/batch", string(emails), &tooMany); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, Actual: %d", code)
+	}
+}
+
+func TestCandidatesAndExtract(t *testing.T) {
+
+	var candidates CandidatesResponse
+	do(t, New(nil), "POST", "/candidates", `{"email": "ev45ive + github @gmail.com", "n": 2}`, &candidates)
+	if len(candidates.Candidates) != 2 || candidates.Candidates[0].Address != "ev45ive@gmail.com" ||
+		candidates.Candidates[1].Address != "ev45ive+github@gmail.com" {
+		t.Errorf("Unexpected candidates: %+v", candidates.Candidates)
+	}
+
+	var extract ExtractResponse
+	do(t, New(nil), "POST", "/extract", `{"text": "Mail zxytim[at]gmail[dot]com today"}`, &extract)
+	if len(extract.Matches) != 1 || extract.Matches[0].Address != "zxytim@gmail.com" ||
+		extract.Matches[0].Start != 5 || extract.Matches[0].End != 28 {
+		t.Errorf("Unexpected matches: %+v", extract.Matches)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+
+	var tests = []struct {
+		method string
+		path   string
+		body   string
+		code   int
+	}{
+		{"GET", "/fix", "", http.StatusMethodNotAllowed},
+		{"POST", "/fix", "not json", http.StatusBadRequest},
+		{"POST", "/fix/batch", `{"emails": "one"}`, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		var resp map[string]*Error
+		code := do(t, New(nil), test.method, test.path, test.body, &resp)
+		if code != test.code {
+			t.Errorf("%s %s: expected status %d, Actual: %d", test.method, test.path, test.code, code)
+		}
+		if resp["error"] == nil || resp["error"].Kind != KindBadRequest {
+			t.Errorf("%s %s: expected a %s error, Actual: %+v", test.method, test.path, KindBadRequest, resp)
+		}
+	}
+
+	var health map[string]string
+	if code := do(t, New(nil), "GET", "/health", "", &health); code != http.StatusOK || health["status"] != "ok" {
+		t.Errorf("Expected healthy, Actual: %d %v", code, health)
+	}
+}
@@ -0,0 +1,152 @@
+package revealer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WithSMTPUTF8 accepts internationalized local parts, such as
+// "用户@例子.中国", validating addresses against RFC 6531 instead of
+// net/mail; without it, local parts must be ASCII. Only ASCII letters
+// are lower cased in the local part, and rules that spell "@" or "."
+// with non-ASCII letters, such as "ät", only match whole words so they
+// cannot eat into a name. StepNormalize only maps the Cyrillic, Greek
+// and Armenian look-alikes of Latin letters, a subset of the Unicode
+// confusables, so a local part may still hide look-alikes from other
+// scripts.
+func WithSMTPUTF8() Option {
+	return func(r *Revealer) {
+		r.smtputf8 = true
+	}
+}
+
+// forSMTPUTF8 returns pairs with those that would change non-ASCII
+// names made whole words, and those that swap one letter for another,
+// such as Cyrillic "а" for "a", dropped. Only alphabets with case are
+// affected; Chinese or Japanese spellings, such as "艾特", still match
+// without spaces around them.
+func forSMTPUTF8(pairs [][2]string) [][2]string {
+	var kept [][2]string
+	for _, pair := range pairs {
+		old := pair[0]
+		if strings.IndexFunc(old, isNonASCIICased) < 0 {
+			kept = append(kept, pair)
+			continue
+		}
+		if utf8.RuneCountInString(old) == 1 {
+			continue
+		}
+		kept = append(kept, [2]string{" " + strings.TrimSpace(old) + " ", pair[1]})
+	}
+	return kept
+}
+
+func isNonASCIICased(r rune) bool {
+	return r >= utf8.RuneSelf && (unicode.IsLower(r) || unicode.IsUpper(r))
+}
+
+// lowerASCII lower cases the ASCII letters in s.
+func lowerASCII(s string) string {
+	return strings.Map(func(r rune) rune {
+		if 'A' <= r && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}, s)
+}
+
+// lowerDomain lower cases everything after the last "@".
+func lowerDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	return email[:at+1] + strings.ToLower(email[at+1:])
+}
+
+// atext is the ASCII allowed in an unquoted local part besides letters
+// and digits (RFC 5322, section 3.2.3).
+const atext = "!#$%&'*+-/=?^_`{|}~"
+
+// validateSMTPUTF8 returns why email is not a bare address as RFC 6531
+// allows, or nil. The local part is a dot-atom or a quoted string, in
+// both of which any printable non-ASCII character may appear, and the
+// domain is a dot-separated list of labels.
+func validateSMTPUTF8(email string) error {
+	if !utf8.ValidString(email) {
+		return errors.New("address is not valid UTF-8")
+	}
+	if len(email) > 254 {
+		return errors.New("address is longer than 254 bytes")
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return errors.New("missing @ in address")
+	}
+	local, domain := email[:at], email[at+1:]
+
+	if len(local) > 64 {
+		return fmt.Errorf("local part %q is longer than 64 bytes", local)
+	}
+	if err := checkLocal(local); err != nil {
+		return fmt.Errorf("local part %q %s", local, err)
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if !isLabel(label) {
+			return fmt.Errorf("domain %q has an invalid label %q", domain, label)
+		}
+	}
+	return nil
+}
+
+// checkLocal returns why local is not a valid dot-atom or quoted
+// string, or nil.
+func checkLocal(local string) error {
+	if len(local) >= 2 && strings.HasPrefix(local, `"`) && strings.HasSuffix(local, `"`) {
+		escaped := false
+		for _, r := range local[1 : len(local)-1] {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"' || !isUTF8Text(r):
+				return fmt.Errorf("has %q in quotes", r)
+			}
+		}
+		if escaped {
+			return errors.New("ends in a backslash")
+		}
+		return nil
+	}
+
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return errors.New("has an empty atom")
+		}
+		for _, r := range atom {
+			if r < utf8.RuneSelf && !isAlnum(byte(r)) && !strings.ContainsRune(atext, r) ||
+				!isUTF8Text(r) {
+				return fmt.Errorf("has %q", r)
+			}
+		}
+	}
+	return nil
+}
+
+// isAlnum reports whether b is an ASCII letter or digit.
+func isAlnum(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
+
+// isUTF8Text reports whether r may appear in a local part: printable
+// ASCII or a printable non-ASCII character (RFC 6532, section 3.1).
+func isUTF8Text(r rune) bool {
+	if r < utf8.RuneSelf {
+		return ' ' <= r && r <= '~'
+	}
+	return r != utf8.RuneError && unicode.IsPrint(r)
+}
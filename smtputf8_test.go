@@ -0,0 +1,103 @@
+package revealer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSMTPUTF8(t *testing.T) {
+
+	var tests = []struct {
+		email    string
+		expected string
+	}{
+		{"用户 at 例子 dot 中国", "用户@例子.中国"},
+		{"δοκιμή at παράδειγμα dot δοκιμή", "δοκιμή@παράδειγμα.δοκιμή"},
+		{"иван at пример dot рф", "иван@пример.рф"},
+		{"Иван at ПРИМЕР dot РФ", "Иван@пример.рф"},
+		{"jürgen ät universität dot de", "jürgen@universität.de"},
+		{"Universität AT example DOT com", "universität@example.com"},
+		{"用户 at 例子 dot 中国 mehr", "用户@例子.中国"},
+		{"test at example dot com", "test@example.com"},
+		{"dexgecko (gmail)", "dexgecko@gmail.com"},
+		{"ali at yıldız dot com", "ali@yıldız.com"},
+	}
+
+	r := New(WithSMTPUTF8())
+	for _, test := range tests {
+		actual, err := r.Fix(test.email)
+		if err != nil {
+			t.Errorf("%s: Error: %s", test.email, err)
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, actual)
+		}
+	}
+
+	// without it, local parts must be ASCII
+	for _, email := range []string{"用户@例子.中国", "δοκιμή@παράδειγμα.δοκιμή", "jürgen@example.de"} {
+		if actual, err := Fix(email); err == nil {
+			t.Errorf("%s: expected an error, Actual: %s", email, actual)
+		}
+		if actual, err := r.Fix(email); err != nil || actual != email {
+			t.Errorf("Expected: %s, Actual: %s %v", email, actual, err)
+		}
+	}
+	for _, email := range []string{"\xff\xff@x.com", "\"\xff\"@x.com"} {
+		if actual, err := Fix(email); err == nil {
+			t.Errorf("%q: expected an error, Actual: %q", email, actual)
+		}
+		if actual, err := r.Fix(email); err == nil {
+			t.Errorf("%q: expected an error, Actual: %q", email, actual)
+		}
+	}
+}
+
+func TestValidateSMTPUTF8(t *testing.T) {
+
+	var tests = []struct {
+		email string
+		valid bool
+	}{
+		{"用户@例子.中国", true},
+		{"δοκιμή@παράδειγμα.δοκιμή", true},
+		{"john.o'neil+tag@example.com", true},
+		{`"john doe"@example.com`, true},
+		{`"джон \"доу\""@пример.рф`, true},
+		{"иван@пример.рф", true},
+		{"user@localhost", true},
+		{"john..doe@example.com", false},
+		{".john@example.com", false},
+		{"john doe@example.com", false},
+		{`"john"doe"@example.com`, false},
+		{`"john\"@example.com`, false},
+		{"john@exa mple.com", false},
+		{"john@example..com", false},
+		{"john", false},
+		{"jo\u200bhn@example.com", false},
+		{"jo\xffhn@example.com", false},
+		{strings.Repeat("я", 33) + "@example.com", false},
+	}
+
+	for _, test := range tests {
+		err := validateSMTPUTF8(test.email)
+		if (err == nil) != test.valid {
+			t.Errorf("%s: expected valid: %t, Actual: %v", test.email, test.valid, err)
+		}
+	}
+}
+
+func TestForSMTPUTF8(t *testing.T) {
+	pairs := forSMTPUTF8([][2]string{{" at ", "@"}, {"ät", "@"}, {"а", "a"}, {"＠", "@"}, {"艾特", "@"}})
+
+	expected := [][2]string{{" at ", "@"}, {" ät ", "@"}, {"＠", "@"}, {"艾特", "@"}}
+	if len(pairs) != len(expected) {
+		t.Fatalf("Expected: %q, Actual: %q", expected, pairs)
+	}
+	for i := range pairs {
+		if pairs[i] != expected[i] {
+			t.Errorf("Expected: %q, Actual: %q", expected[i], pairs[i])
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package revealer
+
+import "log"
+
+// Event describes one pipeline step for a Tracer.
+type Event struct {
+	// Email is the address being revealed, as given.
+	Email string
+
+	// Step is the step that ran (see the Step constants).
+	Step string
+
+	// Input and Output are the address before and after the step.
+	Input  string
+	Output string
+
+	// Changed is true when Output differs from Input.
+	Changed bool
+}
+
+// Tracer receives an Event for each pipeline step that runs.
+type Tracer interface {
+	Trace(e Event)
+}
+
+// TracerFunc lets an ordinary function be used as a Tracer.
+type TracerFunc func(e Event)
+
+// Trace calls f(e).
+func (f TracerFunc) Trace(e Event) {
+	f(e)
+}
+
+// LogTracer returns a Tracer that writes each step to l, or to the
+// standard logger if l is nil, e.g. "Find at:       test@example dot com".
+func LogTracer(l *log.Logger) Tracer {
+	logln := log.Println
+	if l != nil {
+		logln = l.Println
+	}
+	return TracerFunc(func(e Event) {
+		label, ok := stepLabels[e.Step]
+		if !ok {
+			label = e.Step + ":"
+		}
+		paddedStep, err := padding(label, 14, " ")
+		if err != nil {
+			// a long custom stage name goes in as it is
+			paddedStep = label
+		}
+		logln(paddedStep + " " + e.Output)
+	})
+}
+
+// WithTracer sends the steps of every address to t, or of those
+// chosen by WithTraceIf.
+func WithTracer(t Tracer) Option {
+	return func(r *Revealer) {
+		r.tracer = t
+	}
+}
+
+// WithTraceIf only traces the addresses for which trace returns true.
+func WithTraceIf(trace func(email string) bool) Option {
+	return func(r *Revealer) {
+		r.traceIf = trace
+	}
+}
+
+// WithDebugEmail logs each pipeline step with LogTracer when given
+// exactly this email.
+func WithDebugEmail(email string) Option {
+	return func(r *Revealer) {
+		r.tracer = LogTracer(nil)
+		r.traceIf = func(e string) bool {
+			return e == email
+		}
+	}
+}
+
+// RevealTrace is Reveal sending the steps for this one address to t,
+// whatever tracing the Revealer was created with.
+func (r *Revealer) RevealTrace(email string, t Tracer) (*Result, error) {
+	return r.reveal(email, variant{}, t)
+}
+
+// tracerFor returns the Tracer for email, or nil if it is not traced.
+func (r *Revealer) tracerFor(email string) Tracer {
+	if r.tracer == nil || r.traceIf != nil && !r.traceIf(email) {
+		return nil
+	}
+	return r.tracer
+}
@@ -0,0 +1,68 @@
+package revealer
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestTracer(t *testing.T) {
+
+	var events []Event
+	tracer := TracerFunc(func(e Event) {
+		events = append(events, e)
+	})
+
+	r := New(WithTracer(tracer), WithTraceIf(func(email string) bool {
+		return strings.HasPrefix(email, "y.imai")
+	}))
+
+	if _, err := r.Fix("zxytim[at]gmail[dot]com"); err != nil {
+		t.Errorf("Error: %s", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events, Actual: %d", len(events))
+	}
+
+	if _, err := r.Fix("y.imai at ocaml.jp"); err != nil {
+		t.Errorf("Error: %s", err)
+	}
+	if len(events) != 12 {
+		t.Fatalf("Expected: 12 events, Actual: %d", len(events))
+	}
+	first := Event{"y.imai at ocaml.jp", StepUnmarkup, "y.imai at ocaml.jp", "y.imai at ocaml.jp", false}
+	if events[0] != first {
+		t.Errorf("Expected: %+v, Actual: %+v", first, events[0])
+	}
+	fifth := Event{"y.imai at ocaml.jp", StepFindTheAt, "y.imai at ocaml.jp", "y.imai@ocaml.jp", true}
+	if events[4] != fifth {
+		t.Errorf("Expected: %+v, Actual: %+v", fifth, events[4])
+	}
+
+	// RevealTrace traces one call whatever the options
+	events = nil
+	if _, err := New().RevealTrace("zxytim[at]gmail[dot]com", tracer); err != nil {
+		t.Errorf("Error: %s", err)
+	}
+	if len(events) != 12 {
+		t.Errorf("Expected: 12 events, Actual: %d", len(events))
+	}
+}
+
+func TestLogTracer(t *testing.T) {
+
+	var buf bytes.Buffer
+	r := New(WithTracer(LogTracer(log.New(&buf, "", 0))))
+	if _, err := r.Fix("y.imai at ocaml.jp"); err != nil {
+		t.Errorf("Error: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 12 {
+		t.Fatalf("Expected: 12 lines, Actual: %d", len(lines))
+	}
+	if lines[4] != "Find at:       y.imai@ocaml.jp" {
+		t.Errorf("Expected: %q, Actual: %q", "Find at:       y.imai@ocaml.jp", lines[4])
+	}
+}
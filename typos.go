@@ -0,0 +1,171 @@
+package revealer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultTypoDomains are the domains WithTypoCorrection corrects
+// misspellings of, along with those of the providers. Domains close to
+// one another, such as "mail.com", "ymail.com" and "gmail.com", are
+// all listed so that none is taken for a typo of another.
+var DefaultTypoDomains = []string{
+	"gmail.com", "googlemail.com",
+	"hotmail.com", "hotmail.co.uk", "hotmail.fr", "outlook.com", "live.com", "msn.com",
+	"yahoo.com", "yahoo.co.uk", "yahoo.fr", "ymail.com", "rocketmail.com",
+	"aol.com", "icloud.com", "me.com", "mac.com",
+	"mail.com", "email.com", "gmx.com", "gmx.de", "gmx.net", "web.de",
+	"protonmail.com", "proton.me", "zoho.com",
+	"yandex.ru", "mail.ru", "qq.com", "163.com", "126.com",
+	"comcast.net", "verizon.net", "att.net",
+}
+
+// tldTypos are the misspelt top-level domains correctTypos corrects.
+// None is a real top-level domain but "co", Colombia's, which is only
+// corrected after the name of a known domain, as in "gmail.co".
+var tldTypos = map[string]string{
+	"con": "com", "cmo": "com", "ocm": "com", "comm": "com", "coom": "com",
+	"cpm": "com", "vom": "com", "xom": "com", "co": "com",
+	"nte": "net", "ner": "net", "nett": "net",
+	"ogr": "org", "orh": "org", "rog": "org",
+}
+
+// minTypoName is the shortest name correctTypos corrects or corrects
+// to: shorter names, such as "max" or "life", are too often real.
+const minTypoName = 5
+
+// WithTypoCorrection runs the correctTypos step after handcraftedFixes,
+// which corrects typos in a domain close to exactly one of the given
+// domains, or of DefaultTypoDomains if none are given, and of the
+// providers. The name and the top-level domain are corrected apart:
+// a misspelt top-level domain after a known name, as in "gmail.con",
+// or a name of five or more characters one typo away (two for eight
+// or more) under the same suffix, as in "gmial.com". Other domains,
+// including any under another real top-level domain, are never
+// changed. Result.Corrected holds the domain as it was written.
+func WithTypoCorrection(domains ...string) Option {
+	return func(r *Revealer) {
+		if len(domains) == 0 {
+			domains = DefaultTypoDomains
+		}
+		r.typoDomains = nil
+		for _, d := range domains {
+			r.typoDomains = append(r.typoDomains, strings.ToLower(d))
+		}
+		r.steps[StepCorrectTypos] = true
+	}
+}
+
+// correctTypos corrects the domain of email, recording it as written.
+func (f *fix) correctTypos(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	domain := email[at+1:]
+	corrected := f.closestDomain(domain)
+	if corrected == domain {
+		return email
+	}
+	f.corrected = domain
+	return email[:at+1] + corrected
+}
+
+// closestDomain returns the known domain domain is a typo of, or domain.
+func (r *Revealer) closestDomain(domain string) string {
+	var known []string
+	for _, p := range r.providers {
+		known = append(known, p.Domain)
+	}
+	known = append(known, r.typoDomains...)
+	if contains(known, domain) {
+		return domain
+	}
+
+	// a misspelt top-level domain after a known name
+	if dot := strings.LastIndex(domain, "."); dot > 0 {
+		if tld, ok := tldTypos[domain[dot+1:]]; ok && contains(known, domain[:dot+1]+tld) {
+			return domain[:dot+1] + tld
+		}
+	}
+
+	// a misspelt name under the same suffix
+	name, suffix := splitSuffix(domain)
+	if suffix == "" || utf8.RuneCountInString(name) < minTypoName {
+		return domain
+	}
+	best, bestDistance := domain, 0
+	for _, k := range known {
+		kname, ksuffix := splitSuffix(k)
+		if ksuffix != suffix || utf8.RuneCountInString(kname) < minTypoName {
+			continue
+		}
+		d := editDistance(name, kname)
+		switch {
+		case d > maxTypos(kname):
+		case best == domain || d < bestDistance:
+			best, bestDistance = k, d
+		case d == bestDistance && k != best:
+			return domain // too close to call
+		}
+	}
+	return best
+}
+
+// splitSuffix splits domain into the name in front of its public
+// suffix and the suffix, e.g. "hotmail" and "co.uk". The suffix is
+// empty if domain is not under a known one.
+func splitSuffix(domain string) (name, suffix string) {
+	labels := strings.Split(domain, ".")
+	n := suffixLen(labels)
+	if n == 0 || n >= len(labels) {
+		return domain, ""
+	}
+	return strings.Join(labels[:len(labels)-n], "."), strings.Join(labels[len(labels)-n:], ".")
+}
+
+// maxTypos is how many typos may be corrected in name.
+func maxTypos(name string) int {
+	if utf8.RuneCountInString(name) >= 8 {
+		return 2
+	}
+	return 1
+}
+
+// editDistance is the Damerau-Levenshtein distance between a and b: the
+// insertions, deletions, substitutions and transpositions of adjacent
+// characters needed to turn one into the other.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+// minInt returns the smallest of its arguments.
+func minInt(first int, rest ...int) int {
+	for _, n := range rest {
+		if n < first {
+			first = n
+		}
+	}
+	return first
+}
@@ -0,0 +1,121 @@
+package revealer
+
+import (
+	"testing"
+)
+
+func TestTypoCorrection(t *testing.T) {
+
+	var tests = []struct {
+		email     string
+		expected  string
+		corrected string
+	}{
+		{"john@gmial.com", "john@gmail.com", "gmial.com"},
+		{"john@gmail.con", "john@gmail.com", "gmail.con"},
+		{"john@gmail.co", "john@gmail.com", "gmail.co"},
+		{"john@hotmial.com", "john@hotmail.com", "hotmial.com"},
+		{"john@qq.con", "john@qq.com", "qq.con"},
+		{"john@hotmail.ogr", "john@hotmail.ogr", ""},
+		{"john@googlemial.com", "john@googlemail.com", "googlemial.com"},
+		{"john@hotmial.co.uk", "john@hotmail.co.uk", "hotmial.co.uk"},
+		{"john@yahooo.com", "john@yahoo.com", "yahooo.com"},
+		{"john at gmial dot com", "john@gmail.com", "gmial.com"},
+
+		// known domains and those outside the list are left alone
+		{"john@gmail.com", "john@gmail.com", ""},
+		{"john@mail.com", "john@mail.com", ""},
+		{"john@ymail.com", "john@ymail.com", ""},
+		{"john@example.con", "john@example.con", ""},
+		{"john@gmx.org", "john@gmx.org", ""},
+
+		// as are real domains that happen to be close to a known one
+		{"john@max.com", "john@max.com", ""},
+		{"john@life.com", "john@life.com", ""},
+		{"john@love.com", "john@love.com", ""},
+		{"john@mi.com", "john@mi.com", ""},
+		{"john@q.com", "john@q.com", ""},
+		{"john@mail.ro", "john@mail.ro", ""},
+		{"john@web.dk", "john@web.dk", ""},
+		{"john@161.com", "john@161.com", ""},
+		{"john@gmail.cm", "john@gmail.cm", ""},
+		{"john@yahoo.de", "john@yahoo.de", ""},
+
+		// a typo in both the name and the top-level domain is too many
+		{"john@hotmial.con", "john@hotmial.con", ""},
+	}
+
+	r := New(WithTypoCorrection())
+	for _, test := range tests {
+		result, err := r.Reveal(test.email)
+		if err != nil {
+			t.Errorf("%s: Error: %s", test.email, err)
+			continue
+		}
+		if result.Address != test.expected {
+			t.Errorf("Expected: %s, Actual: %s", test.expected, result.Address)
+		}
+		if result.Corrected != test.corrected {
+			t.Errorf("%s: expected corrected %q, Actual: %q", test.email, test.corrected, result.Corrected)
+		}
+		if corrected := contains(result.Steps, StepCorrectTypos); corrected != (test.corrected != "") {
+			t.Errorf("%s: expected corrected: %t, Actual steps: %v", test.email, test.corrected != "", result.Steps)
+		}
+		if test.corrected != "" && !contains(result.Guesses, GuessTypo) {
+			t.Errorf("%s: expected guess %s, Actual: %v", test.email, GuessTypo, result.Guesses)
+		}
+	}
+
+	// typo correction is off by default
+	if result, _ := Fix("john@gmial.com"); result != "john@gmial.com" {
+		t.Errorf("Expected: john@gmial.com, Actual: %s", result)
+	}
+
+	// only the given domains, and the providers, are corrected to
+	r = New(WithTypoCorrection("example.org"))
+	for email, expected := range map[string]string{
+		"john@exmaple.org": "john@example.org",
+		"john@gmial.com":   "john@gmail.com",
+		"john@yahooo.com":  "john@yahooo.com",
+	} {
+		if result, _ := r.Fix(email); result != expected {
+			t.Errorf("Expected: %s, Actual: %s", expected, result)
+		}
+	}
+}
+
+func TestTLDTypos(t *testing.T) {
+	for typo, tld := range tldTypos {
+		if typo != "co" && suffixLen([]string{typo}) > 0 {
+			t.Errorf("%s: Expected a misspelling, Actual: a real top-level domain", typo)
+		}
+		if suffixLen([]string{tld}) != 1 {
+			t.Errorf("%s: Expected a real top-level domain", tld)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+
+	var tests = []struct {
+		a, b     string
+		expected int
+	}{
+		{"gmail.com", "gmail.com", 0},
+		{"gmial.com", "gmail.com", 1},
+		{"gmail.con", "gmail.com", 1},
+		{"gmail.co", "gmail.com", 1},
+		{"yahooo.com", "yahoo.com", 1},
+		{"hotmial.con", "hotmail.com", 2},
+		{"mail.com", "gmail.com", 1},
+		{"", "abc", 3},
+		{"münchen", "mnüchen", 1},
+	}
+
+	for _, test := range tests {
+		actual := editDistance(test.a, test.b)
+		if actual != test.expected {
+			t.Errorf("%s, %s: Expected: %d, Actual: %d", test.a, test.b, test.expected, actual)
+		}
+	}
+}